@@ -2,17 +2,33 @@ package app
 
 import (
 	"fmt"
+	"log"
 
 	"dock-it/internal/docker"
+	"dock-it/internal/settings"
 	"dock-it/internal/ui"
 )
 
 // Run initializes dependencies and starts the UI loop.
 func Run() error {
+	// Bad preferences fall back to the defaults rather than keeping the
+	// application from starting.
+	prefs, err := settings.LoadPreferences()
+	if err != nil {
+		log.Printf("dock-it: ignoring preferences, using defaults: %v", err)
+		prefs = settings.Preferences{}
+	}
+	window, err := prefs.CrashLoop.WindowDuration()
+	if err != nil {
+		log.Printf("dock-it: using the default crash loop window: %v", err)
+		window = 0
+	}
+
 	dockerClient, err := docker.NewClient()
 	if err != nil {
 		return fmt.Errorf("create docker client: %w", err)
 	}
+	dockerClient.SetCrashLoopPolicy(prefs.CrashLoop.Restarts, window)
 
 	interfaceUI := ui.New(dockerClient)
 	interfaceUI.Initialize()
@@ -5,12 +5,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/api/types/volume"
 	"github.com/docker/docker/client"
@@ -19,46 +22,86 @@ import (
 const (
 	defaultTimeout  = 5 * time.Second
 	statsTimeout    = 2 * time.Second
+	inspectTimeout  = 2 * time.Second
 	maxStatsWorkers = 4
 )
 
 // Client wraps the Docker SDK client with high-level helpers consumed by the UI layer.
 type Client struct {
-	cli *client.Client
+	cli        *client.Client
+	crashLoops *CrashLoopDetector
+	inspected  inspectCache
 }
 
 // ContainerInfo holds display information for a single container.
 type ContainerInfo struct {
-	ID      string
-	Name    string
-	Image   string
-	Status  string
-	State   string
-	Ports   string
-	Age     string
-	Created time.Time
-	CPU     string
-	Memory  string
-	NetIO   string
+	ID           string
+	Name         string
+	Image        string
+	ImageID      string
+	Status       string
+	State        string
+	Ports        string
+	PortMappings []PortMapping
+	Age          string
+	Created      time.Time
+	CPU          string
+	Memory       string
+	NetIO        string
+	Usage        *ResourceUsage // nil when no stats were collected, e.g. for stopped containers
+	RestartCount int
+	ExitCode     int
+	OOMKilled    bool
+	StartedAt    time.Time
+	FinishedAt   time.Time
+	CrashLooping bool
+	Outdated     bool     // image tag now resolves to a newer local image
+	Networks     []string // names of attached networks, sorted
+	IPs          []string // IPv4 address on each attached network, in network order
+	Volumes      []string // names of mounted volumes, sorted
+	Labels       map[string]string
+	Command      string
+	Health       string // healthcheck status, empty without a healthcheck
+	SizeRw       int64  // size of the writable layer; only set when the query asks for sizes
+	SizeRootFs   int64  // total size including the image; only set when the query asks for sizes
 }
 
 // ImageInfo holds display information for a Docker image.
 type ImageInfo struct {
-	ID      string
-	Tag     string
-	Size    string
-	Age     string
-	Created time.Time
+	ID        string
+	Tag       string
+	Size      string
+	SizeBytes int64
+	Age       string
+	Created   time.Time
+	Dangling  bool // no repository tags
+	Labels    map[string]string
 }
 
 // NetworkInfo holds display information for a Docker network.
 type NetworkInfo struct {
-	ID      string
-	Name    string
-	Driver  string
-	Scope   string
-	Age     string
-	Created time.Time
+	ID         string
+	Name       string
+	Driver     string
+	Scope      string
+	Age        string
+	Created    time.Time
+	Subnets    []string
+	Gateways   []string
+	Internal   bool
+	Attachable bool
+	Labels     map[string]string
+	Members    []NetworkMember
+}
+
+// NetworkMember describes a container attached to a network.
+type NetworkMember struct {
+	ContainerID string
+	Name        string
+	State       string
+	IPv4        string
+	IPv6        string
+	Aliases     []string // only set by InspectNetworkMembers
 }
 
 // VolumeInfo holds display information for a Docker volume.
@@ -68,6 +111,9 @@ type VolumeInfo struct {
 	Mountpoint string
 	Age        string
 	Created    time.Time
+	Anonymous  bool
+	Labels     map[string]string
+	UsedBy     []VolumeUser
 }
 
 // ContainerStats holds formatted resource usage statistics.
@@ -75,6 +121,7 @@ type ContainerStats struct {
 	CPU    string
 	Memory string
 	NetIO  string
+	Usage  ResourceUsage
 }
 
 // NewClient creates a new Docker client using environment variables.
@@ -83,7 +130,13 @@ func NewClient() (*Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return &Client{cli: cli}, nil
+	return &Client{cli: cli, crashLoops: NewCrashLoopDetector(defaultCrashLoopRestarts, defaultCrashLoopWindow)}, nil
+}
+
+// SetCrashLoopPolicy flags containers that restart more than threshold times
+// within window as crash-looping. Zero values keep the defaults.
+func (c *Client) SetCrashLoopPolicy(threshold int, window time.Duration) {
+	c.crashLoops = NewCrashLoopDetector(threshold, window)
 }
 
 func timeoutCtx(timeout time.Duration) (context.Context, context.CancelFunc) {
@@ -95,18 +148,23 @@ func timeoutCtx(timeout time.Duration) (context.Context, context.CancelFunc) {
 
 // ListContainers retrieves all containers and augments running ones with stats.
 func (c *Client) ListContainers() ([]ContainerInfo, error) {
+	return c.QueryContainers(ContainerQuery{})
+}
+
+// QueryContainers lists the containers matching q.Filters on the daemon,
+// drops those q.Keep rules out, and only inspects and fetches stats for the
+// rest.
+func (c *Client) QueryContainers(q ContainerQuery) ([]ContainerInfo, error) {
 	ctx, cancel := timeoutCtx(defaultTimeout)
 	defer cancel()
 
-	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true, Size: q.Size, Filters: q.Filters})
 	if err != nil {
 		return nil, err
 	}
 
-	var result []ContainerInfo
-	runningContainers := make(map[string]int)
-
-	for i, ctr := range containers {
+	result := make([]ContainerInfo, 0, len(containers))
+	for _, ctr := range containers {
 		name := "<none>"
 		if len(ctr.Names) > 0 {
 			name = strings.TrimPrefix(ctr.Names[0], "/")
@@ -132,6 +190,7 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 			ID:      ctr.ID,
 			Name:    name,
 			Image:   ctr.Image,
+			ImageID: ctr.ImageID,
 			Status:  ctr.Status,
 			State:   ctr.State,
 			Ports:   ports,
@@ -140,44 +199,247 @@ func (c *Client) ListContainers() ([]ContainerInfo, error) {
 			CPU:     "-",
 			Memory:  "-",
 			NetIO:   "-",
+			Labels:  ctr.Labels,
+			Command: ctr.Command,
+
+			PortMappings: portMappings(ctr.Ports),
+			SizeRw:       ctr.SizeRw,
+			SizeRootFs:   ctr.SizeRootFs,
+		}
+		if ctr.NetworkSettings != nil {
+			for netName := range ctr.NetworkSettings.Networks {
+				info.Networks = append(info.Networks, netName)
+			}
+			sort.Strings(info.Networks)
+			for _, netName := range info.Networks {
+				if ep := ctr.NetworkSettings.Networks[netName]; ep != nil && ep.IPAddress != "" {
+					info.IPs = append(info.IPs, ep.IPAddress)
+				}
+			}
 		}
+		for _, mp := range ctr.Mounts {
+			if mp.Type == mount.TypeVolume && mp.Name != "" {
+				info.Volumes = append(info.Volumes, mp.Name)
+			}
+		}
+		sort.Strings(info.Volumes)
 		result = append(result, info)
+	}
+
+	// After the tag a container was created from moves to another image, the
+	// daemon lists the container's image by ID. Its configured reference is
+	// what an update pulls and what outdated is judged by.
+	for i := range result {
+		if !listedByImageID(result[i].Image, result[i].ImageID) {
+			continue
+		}
+		if details, err := c.inspectContainer(result[i].ID, result[i].State, result[i].Status); err == nil && details.Config != nil && details.Config.Image != "" {
+			result[i].Image = details.Config.Image
+		}
+	}
 
-		if ctr.State == "running" {
-			runningContainers[ctr.ID] = i
+	imagesCtx, cancelImages := timeoutCtx(defaultTimeout)
+	localIDs, err := c.localImageIDs(imagesCtx)
+	cancelImages()
+	if err == nil {
+		for i := range result {
+			result[i].Outdated = isOutdated(result[i].Image, result[i].ImageID, localIDs)
 		}
 	}
 
-	if len(runningContainers) > 0 {
-		var wg sync.WaitGroup
-		var mu sync.Mutex
-		sem := make(chan struct{}, maxStatsWorkers)
+	// Crash-loop history and cached inspects are dropped only for
+	// containers an unfiltered listing no longer has. Those the daemon's
+	// filters left out still exist, so narrowing a filter must not reset
+	// their history.
+	var seen map[string]struct{}
+	if q.Filters.Len() == 0 {
+		seen = make(map[string]struct{}, len(result))
+		for i := range result {
+			seen[result[i].ID] = struct{}{}
+		}
+	}
 
-		for id, idx := range runningContainers {
-			wg.Add(1)
-			go func(containerID string, index int) {
-				defer wg.Done()
-				sem <- struct{}{}
-				defer func() { <-sem }()
+	result = c.enrichContainers(q.keep(result, StageListed), q)
 
+	if c.crashLoops != nil {
+		now := time.Now()
+		for i := range result {
+			c.crashLoops.Seed(result[i].ID, result[i].RestartCount, result[i].Created, result[i].StartedAt, now)
+			result[i].CrashLooping = c.crashLoops.Observe(result[i].ID, result[i].RestartCount, now)
+		}
+		if seen != nil {
+			c.crashLoops.Prune(seen)
+		}
+	}
+	if seen != nil {
+		c.inspected.prune(seen)
+	}
+
+	return result, nil
+}
+
+// enrichContainers fills in inspect-only state (restart count, exit code, OOM
+// kill, start/finish times) for every container and stats for running ones
+// that q still keeps once inspected, using a bounded worker pool. Containers
+// whose state and status are unchanged since the last refresh reuse their
+// cached inspect response. It returns the containers q keeps.
+func (c *Client) enrichContainers(result []ContainerInfo, q ContainerQuery) []ContainerInfo {
+	if len(result) == 0 {
+		return result
+	}
+	dropped := make([]bool, len(result))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	sem := make(chan struct{}, maxStatsWorkers)
+
+	for idx := range result {
+		wg.Add(1)
+		go func(index int, containerID, state, status string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			running := state == "running"
+			details, inspectErr := c.inspectContainer(containerID, state, status)
+
+			keep := true
+			if inspectErr == nil && q.Keep != nil {
+				mu.Lock()
+				inspected := result[index]
+				mu.Unlock()
+				applyInspectState(&inspected, details)
+				keep = q.Keep(inspected, StageInspected)
+			}
+
+			var stats *ContainerStats
+			var statsErr error
+			if running && keep {
 				statsCtx, cancelStats := timeoutCtx(statsTimeout)
-				defer cancelStats()
-
-				stats, err := c.getContainerStatsWithContext(statsCtx, containerID)
-				if err == nil {
-					mu.Lock()
-					result[index].CPU = stats.CPU
-					result[index].Memory = stats.Memory
-					result[index].NetIO = stats.NetIO
-					mu.Unlock()
-				}
-			}(id, idx)
+				stats, statsErr = c.getContainerStatsWithContext(statsCtx, containerID)
+				cancelStats()
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			if !keep {
+				dropped[index] = true
+				return
+			}
+			if inspectErr == nil {
+				applyInspectState(&result[index], details)
+			}
+			if running && statsErr == nil {
+				result[index].CPU = stats.CPU
+				result[index].Memory = stats.Memory
+				result[index].NetIO = stats.NetIO
+				usage := stats.Usage
+				result[index].Usage = &usage
+			}
+		}(idx, result[idx].ID, result[idx].State, result[idx].Status)
+	}
+
+	wg.Wait()
+
+	kept := result[:0]
+	for i, info := range result {
+		if !dropped[i] {
+			kept = append(kept, info)
 		}
+	}
+	return kept
+}
 
-		wg.Wait()
+// inspectContainer returns the inspect response of a container listed with
+// the given state and status, reusing the cached one while both are
+// unchanged.
+func (c *Client) inspectContainer(id, state, status string) (container.InspectResponse, error) {
+	if details, ok := c.inspected.get(id, state, status); ok {
+		return details, nil
 	}
+	ctx, cancel := timeoutCtx(inspectTimeout)
+	defer cancel()
+	details, err := c.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return container.InspectResponse{}, err
+	}
+	c.inspected.put(id, state, status, details)
+	return details, nil
+}
 
-	return result, nil
+// listedByImageID reports whether the daemon listed a container's image by
+// its ID rather than by the reference the container was created with.
+func listedByImageID(image, imageID string) bool {
+	if strings.HasPrefix(image, "sha256:") {
+		return true
+	}
+	return len(image) >= 12 && strings.HasPrefix(strings.TrimPrefix(imageID, "sha256:"), image)
+}
+
+// inspectCache keeps the last inspect response of each container together
+// with the state and status the container was listed with at the time. The
+// daemon renders the status from the start and finish times, exit code and
+// health, so while both are unchanged the container has not restarted and
+// its cached inspect response is still current.
+type inspectCache struct {
+	mu      sync.Mutex
+	entries map[string]inspectEntry
+}
+
+type inspectEntry struct {
+	state   string
+	status  string
+	details container.InspectResponse
+}
+
+// get returns the cached inspect response of a container if it was taken at
+// the given state and status.
+func (c *inspectCache) get(id, state, status string) (container.InspectResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[id]
+	if !ok || e.state != state || e.status != status {
+		return container.InspectResponse{}, false
+	}
+	return e.details, true
+}
+
+func (c *inspectCache) put(id, state, status string, details container.InspectResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]inspectEntry)
+	}
+	c.entries[id] = inspectEntry{state: state, status: status, details: details}
+}
+
+// prune forgets containers that are no longer present.
+func (c *inspectCache) prune(present map[string]struct{}) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for id := range c.entries {
+		if _, ok := present[id]; !ok {
+			delete(c.entries, id)
+		}
+	}
+}
+
+// applyInspectState copies lifecycle details from an inspect response onto info.
+func applyInspectState(info *ContainerInfo, details container.InspectResponse) {
+	if details.ContainerJSONBase == nil {
+		return
+	}
+	info.RestartCount = details.RestartCount
+	if details.State == nil {
+		return
+	}
+	info.ExitCode = details.State.ExitCode
+	info.OOMKilled = details.State.OOMKilled
+	info.StartedAt = parseDockerTime(details.State.StartedAt)
+	info.FinishedAt = parseDockerTime(details.State.FinishedAt)
+	if details.State.Health != nil {
+		info.Health = string(details.State.Health.Status)
+	}
 }
 
 func (c *Client) getContainerStats(id string) (*ContainerStats, error) {
@@ -197,36 +459,12 @@ func (c *Client) getContainerStatsWithContext(ctx context.Context, id string) (*
 		return nil, err
 	}
 
-	cpuDelta := float64(payload.CPUStats.CPUUsage.TotalUsage - payload.PreCPUStats.CPUUsage.TotalUsage)
-	systemDelta := float64(payload.CPUStats.SystemUsage - payload.PreCPUStats.SystemUsage)
-	onlineCPUs := float64(payload.CPUStats.OnlineCPUs)
-	if onlineCPUs == 0 && len(payload.CPUStats.CPUUsage.PercpuUsage) > 0 {
-		onlineCPUs = float64(len(payload.CPUStats.CPUUsage.PercpuUsage))
-	}
-
-	cpuPercent := 0.0
-	if cpuDelta > 0 && systemDelta > 0 && onlineCPUs > 0 {
-		cpuPercent = (cpuDelta / systemDelta) * onlineCPUs * 100.0
-	}
-
-	memUsage := float64(payload.MemoryStats.Usage)
-	memLimit := float64(payload.MemoryStats.Limit)
-	memPercent := 0.0
-	if memLimit > 0 {
-		memPercent = (memUsage / memLimit) * 100.0
-	}
-
-	rxBytes := 0.0
-	txBytes := 0.0
-	for _, netStats := range payload.Networks {
-		rxBytes += float64(netStats.RxBytes)
-		txBytes += float64(netStats.TxBytes)
-	}
-
+	usage := usageFromStats(payload)
 	return &ContainerStats{
-		CPU:    fmt.Sprintf("%.2f%%", cpuPercent),
-		Memory: fmt.Sprintf("%.2f%%", memPercent),
-		NetIO:  fmt.Sprintf("%.1fMB/%.1fMB", rxBytes/(1024*1024), txBytes/(1024*1024)),
+		CPU:    fmt.Sprintf("%.2f%%", usage.CPUPercent),
+		Memory: fmt.Sprintf("%.2f%%", usage.MemoryPercent),
+		NetIO:  fmt.Sprintf("%.1fMB/%.1fMB", float64(usage.NetRxBytes)/(1024*1024), float64(usage.NetTxBytes)/(1024*1024)),
+		Usage:  usage,
 	}, nil
 }
 
@@ -285,10 +523,15 @@ func (c *Client) ExecContainer(id string) error {
 }
 
 func (c *Client) ListImages() ([]ImageInfo, error) {
+	return c.QueryImages(filters.NewArgs())
+}
+
+// QueryImages lists the images matching args on the daemon.
+func (c *Client) QueryImages(args filters.Args) ([]ImageInfo, error) {
 	ctx, cancel := timeoutCtx(defaultTimeout)
 	defer cancel()
 
-	images, err := c.cli.ImageList(ctx, image.ListOptions{All: true})
+	images, err := c.cli.ImageList(ctx, image.ListOptions{All: true, Filters: args})
 	if err != nil {
 		return nil, err
 	}
@@ -296,8 +539,10 @@ func (c *Client) ListImages() ([]ImageInfo, error) {
 	var result []ImageInfo
 	for _, img := range images {
 		tag := "<none>"
+		dangling := true
 		if len(img.RepoTags) > 0 {
 			tag = img.RepoTags[0]
+			dangling = isDanglingTags(img.RepoTags)
 		}
 
 		size := fmt.Sprintf("%.2f MB", float64(img.Size)/(1024*1024))
@@ -305,11 +550,14 @@ func (c *Client) ListImages() ([]ImageInfo, error) {
 		age := formatRelativeDuration(time.Since(createdTime))
 
 		info := ImageInfo{
-			ID:      shortImageID(img.ID),
-			Tag:     tag,
-			Size:    size,
-			Age:     age,
-			Created: createdTime,
+			ID:        shortImageID(img.ID),
+			Tag:       tag,
+			Size:      size,
+			SizeBytes: img.Size,
+			Age:       age,
+			Created:   createdTime,
+			Dangling:  dangling,
+			Labels:    img.Labels,
 		}
 		result = append(result, info)
 	}
@@ -318,13 +566,26 @@ func (c *Client) ListImages() ([]ImageInfo, error) {
 }
 
 func (c *Client) ListNetworks() ([]NetworkInfo, error) {
+	return c.QueryNetworks(filters.NewArgs())
+}
+
+// QueryNetworks lists the networks matching args on the daemon.
+func (c *Client) QueryNetworks(args filters.Args) ([]NetworkInfo, error) {
 	ctx, cancel := timeoutCtx(defaultTimeout)
 	defer cancel()
 
-	networks, err := c.cli.NetworkList(ctx, network.ListOptions{})
+	networks, err := c.cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return nil, err
+	}
+
+	// Network list responses do not include endpoints, so attachments are
+	// derived from the container list.
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
 	if err != nil {
 		return nil, err
 	}
+	members := networkMembers(containers)
 
 	var result []NetworkInfo
 	for _, net := range networks {
@@ -333,18 +594,20 @@ func (c *Client) ListNetworks() ([]NetworkInfo, error) {
 			id = id[:12]
 		}
 
-		// Docker network API doesn't always provide Created timestamp
-		// Set age to "-" as it's not reliably available
-		age := "-"
-		var createdTime time.Time
-
+		subnets, gateways := ipamSummary(net.IPAM)
 		info := NetworkInfo{
-			ID:      id,
-			Name:    net.Name,
-			Driver:  net.Driver,
-			Scope:   net.Scope,
-			Age:     age,
-			Created: createdTime,
+			ID:         id,
+			Name:       net.Name,
+			Driver:     net.Driver,
+			Scope:      net.Scope,
+			Age:        FormatAge(net.Created),
+			Created:    net.Created,
+			Subnets:    subnets,
+			Gateways:   gateways,
+			Internal:   net.Internal,
+			Attachable: net.Attachable,
+			Labels:     net.Labels,
+			Members:    members[net.ID],
 		}
 		result = append(result, info)
 	}
@@ -353,14 +616,25 @@ func (c *Client) ListNetworks() ([]NetworkInfo, error) {
 }
 
 func (c *Client) ListVolumes() ([]VolumeInfo, error) {
+	return c.QueryVolumes(filters.NewArgs())
+}
+
+// QueryVolumes lists the volumes matching args on the daemon.
+func (c *Client) QueryVolumes(args filters.Args) ([]VolumeInfo, error) {
 	ctx, cancel := timeoutCtx(defaultTimeout)
 	defer cancel()
 
-	volumes, err := c.cli.VolumeList(ctx, volume.ListOptions{})
+	volumes, err := c.cli.VolumeList(ctx, volume.ListOptions{Filters: args})
 	if err != nil {
 		return nil, err
 	}
 
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	if err != nil {
+		return nil, err
+	}
+	users := volumeUsers(containers)
+
 	var result []VolumeInfo
 	for _, vol := range volumes.Volumes {
 		// Parse CreatedAt timestamp if available
@@ -383,6 +657,9 @@ func (c *Client) ListVolumes() ([]VolumeInfo, error) {
 			Mountpoint: vol.Mountpoint,
 			Age:        age,
 			Created:    createdTime,
+			Anonymous:  isAnonymousVolume(vol.Name, vol.Labels),
+			Labels:     vol.Labels,
+			UsedBy:     users[vol.Name],
 		}
 		result = append(result, info)
 	}
@@ -453,6 +730,19 @@ func (c *Client) DescribeVolume(name string) (string, error) {
 	return formatAsJSON(data)
 }
 
+// parseDockerTime parses an RFC 3339 timestamp returned by the daemon. Docker
+// reports unset times as "0001-01-01T00:00:00Z", which maps to the zero time.
+func parseDockerTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	parsed, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil || parsed.Year() <= 1 {
+		return time.Time{}
+	}
+	return parsed
+}
+
 func formatAsJSON(v interface{}) (string, error) {
 	buf, err := json.MarshalIndent(v, "", "  ")
 	if err != nil {
@@ -476,6 +766,14 @@ func shortImageID(id string) string {
 	return id
 }
 
+// FormatAge renders the time elapsed since t, or "-" when t is unset.
+func FormatAge(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return formatRelativeDuration(time.Since(t))
+}
+
 func formatRelativeDuration(d time.Duration) string {
 	if d < 0 {
 		d = -d
@@ -0,0 +1,104 @@
+package docker
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	defaultCrashLoopRestarts = 3
+	defaultCrashLoopWindow   = 5 * time.Minute
+)
+
+type restartSample struct {
+	at    time.Time
+	count int
+}
+
+// CrashLoopDetector tracks restart counts across refreshes and flags containers
+// that restarted more than a threshold number of times within a sliding window.
+type CrashLoopDetector struct {
+	mu        sync.Mutex
+	threshold int
+	window    time.Duration
+	samples   map[string][]restartSample
+}
+
+// NewCrashLoopDetector creates a detector that flags containers with more than
+// threshold restarts inside window.
+func NewCrashLoopDetector(threshold int, window time.Duration) *CrashLoopDetector {
+	if threshold <= 0 {
+		threshold = defaultCrashLoopRestarts
+	}
+	if window <= 0 {
+		window = defaultCrashLoopWindow
+	}
+	return &CrashLoopDetector{
+		threshold: threshold,
+		window:    window,
+		samples:   make(map[string][]restartSample),
+	}
+}
+
+// Observe records the restart count seen for a container at the given time and
+// reports whether the container is currently crash-looping.
+func (d *CrashLoopDetector) Observe(id string, restartCount int, now time.Time) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	history := d.samples[id]
+	// A lower count means the container was recreated under the same ID or the
+	// daemon restarted; start over rather than report a negative delta.
+	if n := len(history); n > 0 && restartCount < history[n-1].count {
+		history = nil
+	}
+	history = append(history, restartSample{at: now, count: restartCount})
+
+	// Only samples inside the window count; the oldest of them is the
+	// baseline the restarts are counted from.
+	cutoff := now.Add(-d.window)
+	start := 0
+	for history[start].at.Before(cutoff) {
+		start++
+	}
+	history = history[start:]
+	d.samples[id] = history
+
+	return restartCount-history[0].count > d.threshold
+}
+
+// Seed gives a container seen for the first time a baseline, so a loop
+// that was already running is flagged by the first Observe instead of only
+// after several refreshes. Its restarts since created are assumed to be
+// spread evenly, which only holds for a container that keeps restarting,
+// so it is not seeded unless it last started inside the window. Containers
+// with history are left alone.
+func (d *CrashLoopDetector) Seed(id string, restartCount int, created, started, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	cutoff := now.Add(-d.window)
+	if len(d.samples[id]) > 0 || restartCount == 0 || !started.After(cutoff) || !created.Before(now) {
+		return
+	}
+	baseline := restartSample{at: created}
+	if created.Before(cutoff) {
+		baseline = restartSample{
+			at:    cutoff,
+			count: int(float64(restartCount) * float64(cutoff.Sub(created)) / float64(now.Sub(created))),
+		}
+	}
+	d.samples[id] = []restartSample{baseline}
+}
+
+// Prune forgets containers that are no longer present.
+func (d *CrashLoopDetector) Prune(present map[string]struct{}) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for id := range d.samples {
+		if _, ok := present[id]; !ok {
+			delete(d.samples, id)
+		}
+	}
+}
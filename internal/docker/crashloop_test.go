@@ -0,0 +1,221 @@
+package docker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+func TestCrashLoopDetector(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		samples []struct {
+			offset time.Duration
+			count  int
+		}
+		want bool
+	}{
+		{
+			name: "firstObservation",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 50}},
+			want: false,
+		},
+		{
+			name: "restartsWithinWindow",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 1}, {time.Minute, 3}, {2 * time.Minute, 5}},
+			want: true,
+		},
+		{
+			name: "restartsAtThreshold",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 1}, {time.Minute, 4}},
+			want: false,
+		},
+		{
+			name: "restartsOutsideWindow",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 1}, {time.Minute, 6}, {20 * time.Minute, 7}},
+			want: false,
+		},
+		{
+			name: "sparseObservations",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 1}, {20 * time.Minute, 7}},
+			want: false,
+		},
+		{
+			name: "baselineAgesOut",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 1}, {4 * time.Minute, 5}, {6 * time.Minute, 5}},
+			want: false,
+		},
+		{
+			name: "counterReset",
+			samples: []struct {
+				offset time.Duration
+				count  int
+			}{{0, 10}, {time.Minute, 0}},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := NewCrashLoopDetector(3, 5*time.Minute)
+			var got bool
+			for _, s := range tt.samples {
+				got = d.Observe("abc", s.count, start.Add(s.offset))
+			}
+			if got != tt.want {
+				t.Fatalf("Observe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCrashLoopDetectorSeed(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		restarts int
+		created  time.Duration // before now
+		started  time.Duration // before now
+		want     bool
+	}{
+		{"loopingSinceCreated", 50, time.Hour, 10 * time.Second, true},
+		{"createdInsideWindow", 5, 2 * time.Minute, 5 * time.Second, true},
+		{"slowRestarts", 50, 30 * 24 * time.Hour, time.Minute, false},
+		{"stableSinceLastRestart", 50, time.Hour, 30 * time.Minute, false},
+		{"neverRestarted", 0, time.Hour, time.Hour, false},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			d := NewCrashLoopDetector(3, 5*time.Minute)
+			d.Seed("abc", tt.restarts, now.Add(-tt.created), now.Add(-tt.started), now)
+			if got := d.Observe("abc", tt.restarts, now); got != tt.want {
+				t.Fatalf("first Observe() after Seed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	// A seeded baseline does not replace history.
+	d := NewCrashLoopDetector(3, 5*time.Minute)
+	d.Observe("abc", 50, now.Add(-time.Minute))
+	d.Seed("abc", 50, now.Add(-time.Hour), now.Add(-time.Second), now)
+	if d.Observe("abc", 50, now) {
+		t.Fatalf("expected no loop without restarts since the last observation")
+	}
+}
+
+func TestCrashLoopDetectorPrune(t *testing.T) {
+	t.Parallel()
+
+	d := NewCrashLoopDetector(1, time.Minute)
+	now := time.Now()
+	d.Observe("keep", 0, now)
+	d.Observe("drop", 0, now)
+	d.Prune(map[string]struct{}{"keep": {}})
+
+	if _, ok := d.samples["drop"]; ok {
+		t.Fatalf("expected pruned container to be forgotten")
+	}
+	if _, ok := d.samples["keep"]; !ok {
+		t.Fatalf("expected present container to be kept")
+	}
+}
+
+func TestQueryContainersPrunesOnlyUnfiltered(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClient(t, fakeDaemon{
+		"GET /containers/json": []container.Summary{{ID: "web", Names: []string{"/web"}, State: "running", Status: "Up 2 hours"}},
+		"GET /containers/web/json": container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "web", State: &container.State{Status: "running"}},
+		},
+	})
+	c.crashLoops = NewCrashLoopDetector(1, time.Minute)
+	c.crashLoops.Observe("db", 2, time.Now())
+
+	// db is not listed because the daemon filtered it out, not because it
+	// is gone.
+	if _, err := c.QueryContainers(ContainerQuery{Filters: filters.NewArgs(filters.Arg("name", "web"))}); err != nil {
+		t.Fatalf("QueryContainers() error = %v", err)
+	}
+	if _, ok := c.crashLoops.samples["db"]; !ok {
+		t.Fatalf("expected a filtered listing to keep db's history")
+	}
+
+	if _, err := c.ListContainers(); err != nil {
+		t.Fatalf("ListContainers() error = %v", err)
+	}
+	if _, ok := c.crashLoops.samples["db"]; ok {
+		t.Fatalf("expected an unfiltered listing without db to forget it")
+	}
+}
+
+func TestParseDockerTime(t *testing.T) {
+	t.Parallel()
+
+	if got := parseDockerTime("0001-01-01T00:00:00Z"); !got.IsZero() {
+		t.Fatalf("parseDockerTime(zero) = %v, want zero time", got)
+	}
+	if got := parseDockerTime(""); !got.IsZero() {
+		t.Fatalf("parseDockerTime(empty) = %v, want zero time", got)
+	}
+	want := time.Date(2025, 1, 1, 10, 0, 0, 123000000, time.UTC)
+	if got := parseDockerTime("2025-01-01T10:00:00.123Z"); !got.Equal(want) {
+		t.Fatalf("parseDockerTime() = %v, want %v", got, want)
+	}
+}
+
+func TestInspectCache(t *testing.T) {
+	t.Parallel()
+
+	var c inspectCache
+	if _, ok := c.get("abc", "running", "Up 2 hours"); ok {
+		t.Fatalf("expected miss on empty cache")
+	}
+
+	details := container.InspectResponse{ContainerJSONBase: &container.ContainerJSONBase{RestartCount: 4}}
+	c.put("abc", "running", "Up 2 hours", details)
+	if got, ok := c.get("abc", "running", "Up 2 hours"); !ok || got.RestartCount != 4 {
+		t.Fatalf("get() = %+v, %v, want cached response", got, ok)
+	}
+	if _, ok := c.get("abc", "running", "Up 3 seconds"); ok {
+		t.Fatalf("expected miss after the status changed")
+	}
+	if _, ok := c.get("abc", "restarting", "Up 2 hours"); ok {
+		t.Fatalf("expected miss after the state changed")
+	}
+
+	c.prune(map[string]struct{}{})
+	if _, ok := c.get("abc", "running", "Up 2 hours"); ok {
+		t.Fatalf("expected pruned container to be forgotten")
+	}
+}
@@ -0,0 +1,377 @@
+package docker
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+const probeTimeout = 10 * time.Second
+
+// Probe kinds and statuses reported by DiagnoseConnectivity.
+const (
+	ProbeDNS = "dns"
+	ProbeTCP = "tcp"
+
+	ProbePass    = "pass"
+	ProbeFail    = "fail"
+	ProbeSkipped = "skipped"
+)
+
+// ConnectivityReport describes how a source container can reach a target.
+type ConnectivityReport struct {
+	Source   string
+	Target   string
+	Shared   []SharedNetwork
+	Ports    []string // exposed TCP ports of the target
+	Probes   []ProbeResult
+	Problems []string // findings that explain why probes cannot succeed
+}
+
+// SharedNetwork is a network both containers are attached to.
+type SharedNetwork struct {
+	Name     string
+	TargetIP string
+	// Names are the DNS names the source can use for the target. They are
+	// empty on the default bridge, which has no embedded DNS.
+	Names []string
+}
+
+// ProbeResult is the outcome of a single reachability check run from the
+// source container.
+type ProbeResult struct {
+	Kind   string
+	Target string
+	Status string
+	Tool   string
+	Reason string
+}
+
+// Failed reports whether any probe failed or a problem was found.
+func (r ConnectivityReport) Failed() bool {
+	if len(r.Problems) > 0 {
+		return true
+	}
+	for _, p := range r.Probes {
+		if p.Status == ProbeFail {
+			return true
+		}
+	}
+	return false
+}
+
+// probe is a planned reachability check.
+type probe struct {
+	Kind string
+	Host string
+	Port string
+}
+
+func (p probe) target() string {
+	if p.Kind == ProbeTCP {
+		return p.Host + ":" + p.Port
+	}
+	return p.Host
+}
+
+// DiagnoseConnectivity works out the networks and names source can use to
+// reach target and runs DNS and TCP probes from inside source. Probes use
+// whichever tools the source image provides.
+func (c *Client) DiagnoseConnectivity(sourceID, targetID string) (ConnectivityReport, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	src, err := c.cli.ContainerInspect(ctx, sourceID)
+	if err != nil {
+		cancel()
+		return ConnectivityReport{}, fmt.Errorf("inspect source: %w", err)
+	}
+	dst, err := c.cli.ContainerInspect(ctx, targetID)
+	cancel()
+	if err != nil {
+		return ConnectivityReport{}, fmt.Errorf("inspect target: %w", err)
+	}
+
+	report, probes := planConnectivity(src, dst)
+	if src.State == nil || !src.State.Running {
+		report.Problems = append(report.Problems, fmt.Sprintf("source %s is not running, probes cannot be executed", report.Source))
+		return report, nil
+	}
+
+	for _, p := range probes {
+		probeCtx, cancelProbe := timeoutCtx(probeTimeout)
+		output, exitCode, err := c.execCapture(probeCtx, src.ID, p.command())
+		cancelProbe()
+		if err != nil {
+			report.Probes = append(report.Probes, ProbeResult{
+				Kind:   p.Kind,
+				Target: p.target(),
+				Status: ProbeFail,
+				Reason: err.Error(),
+			})
+			continue
+		}
+		report.Probes = append(report.Probes, classifyProbe(p, exitCode, output))
+	}
+	return report, nil
+}
+
+// planConnectivity inspects the static configuration of both containers and
+// decides which probes to run.
+func planConnectivity(src, dst container.InspectResponse) (ConnectivityReport, []probe) {
+	report := ConnectivityReport{
+		Source: containerName(src),
+		Target: containerName(dst),
+	}
+
+	if dst.State == nil || !dst.State.Running {
+		report.Problems = append(report.Problems, fmt.Sprintf("target %s is not running", report.Target))
+	}
+	if dst.Config != nil {
+		report.Ports = exposedTCPPorts(dst.Config.ExposedPorts)
+		if len(report.Ports) < len(dst.Config.ExposedPorts) {
+			report.Problems = append(report.Problems, "UDP/SCTP ports are exposed but not probed")
+		}
+	}
+
+	if src.HostConfig != nil && src.HostConfig.NetworkMode.IsHost() {
+		report.Problems = append(report.Problems, fmt.Sprintf("source %s uses host networking; it can only reach %s through published ports", report.Source, report.Target))
+	}
+
+	report.Shared = sharedNetworks(src, dst)
+	if len(report.Shared) == 0 {
+		report.Problems = append(report.Problems, "the containers share no network; connect both to a common user-defined network")
+		return report, nil
+	}
+
+	var probes []probe
+	seen := make(map[string]struct{})
+	dnsAvailable := false
+	for _, n := range report.Shared {
+		if len(n.Names) == 0 {
+			continue
+		}
+		dnsAvailable = true
+		for _, name := range n.Names {
+			if _, ok := seen[name]; ok {
+				continue
+			}
+			seen[name] = struct{}{}
+			probes = append(probes, probe{Kind: ProbeDNS, Host: name})
+		}
+	}
+	if !dnsAvailable {
+		report.Problems = append(report.Problems, "only the default bridge is shared; it has no embedded DNS, so the target is reachable by IP only")
+	}
+
+	for _, n := range report.Shared {
+		if n.TargetIP == "" {
+			continue
+		}
+		for _, port := range report.Ports {
+			probes = append(probes, probe{Kind: ProbeTCP, Host: n.TargetIP, Port: port})
+		}
+	}
+	// Also connect by name so a stale or wrong DNS answer shows up as a
+	// difference between the name and IP probes.
+	for _, n := range report.Shared {
+		if len(n.Names) == 0 {
+			continue
+		}
+		for _, port := range report.Ports {
+			probes = append(probes, probe{Kind: ProbeTCP, Host: n.Names[0], Port: port})
+		}
+		break
+	}
+	if len(report.Ports) == 0 {
+		report.Problems = append(report.Problems, fmt.Sprintf("target %s exposes no TCP ports; only name resolution was checked", report.Target))
+	}
+	return report, probes
+}
+
+// sharedNetworks returns the networks both containers are attached to, with
+// the target's address and DNS names on each.
+func sharedNetworks(src, dst container.InspectResponse) []SharedNetwork {
+	if src.NetworkSettings == nil || dst.NetworkSettings == nil {
+		return nil
+	}
+	var shared []SharedNetwork
+	for name, dstEP := range dst.NetworkSettings.Networks {
+		srcEP, ok := src.NetworkSettings.Networks[name]
+		if !ok || srcEP == nil || dstEP == nil {
+			continue
+		}
+		n := SharedNetwork{Name: name, TargetIP: dstEP.IPAddress}
+		if name != network.NetworkBridge {
+			n.Names = endpointNames(containerName(dst), dst.ID, dstEP)
+		}
+		shared = append(shared, n)
+	}
+	sort.Slice(shared, func(i, j int) bool { return shared[i].Name < shared[j].Name })
+	return shared
+}
+
+// endpointNames lists the names the embedded DNS server answers for an
+// endpoint: the container name followed by its aliases.
+func endpointNames(name, id string, ep *network.EndpointSettings) []string {
+	candidates := append([]string{name}, ep.DNSNames...)
+	candidates = append(candidates, userAliases(ep.Aliases, id)...)
+
+	var names []string
+	seen := make(map[string]struct{})
+	for _, n := range candidates {
+		if _, ok := seen[n]; ok || n == "" || (len(id) >= 12 && n == id[:12]) {
+			continue
+		}
+		seen[n] = struct{}{}
+		names = append(names, n)
+	}
+	return names
+}
+
+func exposedTCPPorts(ports nat.PortSet) []string {
+	var out []string
+	for port := range ports {
+		if port.Proto() == "tcp" {
+			out = append(out, port.Port())
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if len(out[i]) != len(out[j]) {
+			return len(out[i]) < len(out[j])
+		}
+		return out[i] < out[j]
+	})
+	return out
+}
+
+// dnsProbeScript resolves $1 with the first lookup tool found in the image.
+const dnsProbeScript = `for t in getent nslookup host; do
+  if command -v "$t" >/dev/null 2>&1; then
+    echo "tool=$t"
+    if [ "$t" = getent ]; then getent hosts "$1"; else "$t" "$1"; fi
+    exit $?
+  fi
+done
+echo "tool=none"
+exit 127`
+
+// tcpProbeScript opens a TCP connection to $1:$2 with the first suitable
+// tool found in the image.
+const tcpProbeScript = `if command -v nc >/dev/null 2>&1; then
+  echo "tool=nc"; nc -z -w 3 "$1" "$2"; exit $?
+fi
+if command -v bash >/dev/null 2>&1; then
+  echo "tool=bash"
+  if command -v timeout >/dev/null 2>&1; then
+    timeout 3 bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$1" "$2"
+  else
+    bash -c 'exec 3<>"/dev/tcp/$0/$1"' "$1" "$2"
+  fi
+  exit $?
+fi
+for py in python3 python; do
+  if command -v "$py" >/dev/null 2>&1; then
+    echo "tool=$py"
+    "$py" -c 'import socket,sys; socket.create_connection((sys.argv[1], int(sys.argv[2])), 3)' "$1" "$2"
+    exit $?
+  fi
+done
+echo "tool=none"
+exit 127`
+
+func (p probe) command() []string {
+	if p.Kind == ProbeTCP {
+		return []string{"sh", "-c", tcpProbeScript, "sh", p.Host, p.Port}
+	}
+	return []string{"sh", "-c", dnsProbeScript, "sh", p.Host}
+}
+
+// classifyProbe turns the output of a probe script into a result.
+func classifyProbe(p probe, exitCode int, output string) ProbeResult {
+	result := ProbeResult{Kind: p.Kind, Target: p.target()}
+
+	var lines []string
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if tool, ok := strings.CutPrefix(line, "tool="); ok && result.Tool == "" {
+			result.Tool = tool
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	switch {
+	case result.Tool == "" && (exitCode == 126 || exitCode == 127):
+		result.Status = ProbeSkipped
+		result.Reason = "source image has no /bin/sh"
+	case result.Tool == "none":
+		result.Status = ProbeSkipped
+		if p.Kind == ProbeTCP {
+			result.Reason = "no TCP client in source image (tried nc, bash, python)"
+		} else {
+			result.Reason = "no DNS tool in source image (tried getent, nslookup, host)"
+		}
+		result.Tool = ""
+	case exitCode == 0:
+		result.Status = ProbePass
+		if p.Kind == ProbeDNS && len(lines) > 0 {
+			result.Reason = resolvedAddress(lines)
+		} else {
+			result.Reason = "connected"
+		}
+	default:
+		result.Status = ProbeFail
+		switch {
+		case p.Kind == ProbeTCP && exitCode == 124:
+			result.Reason = "connection timed out"
+		case len(lines) > 0:
+			result.Reason = lines[len(lines)-1]
+		case p.Kind == ProbeDNS:
+			result.Reason = "name did not resolve"
+		default:
+			result.Reason = fmt.Sprintf("connection failed (exit %d)", exitCode)
+		}
+	}
+	return result
+}
+
+// resolvedAddress picks a short description of a successful lookup from the
+// tool output.
+func resolvedAddress(lines []string) string {
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if addr, ok := strings.CutPrefix(line, "Address:"); ok {
+			return "resolves to " + strings.TrimSpace(addr)
+		}
+		if strings.Contains(line, " has address ") {
+			return "resolves to " + line[strings.LastIndex(line, " ")+1:]
+		}
+	}
+	if fields := strings.Fields(lines[0]); len(fields) > 1 {
+		return "resolves to " + fields[0]
+	}
+	return "resolved"
+}
+
+func containerName(details container.InspectResponse) string {
+	if details.ContainerJSONBase == nil {
+		return ""
+	}
+	if name := strings.TrimPrefix(details.Name, "/"); name != "" {
+		return name
+	}
+	return shortContainerID(details.ID)
+}
+
+func shortContainerID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
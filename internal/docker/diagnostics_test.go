@@ -0,0 +1,152 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+func inspectWithNetworks(id, name string, running bool, ports nat.PortSet, networks map[string]*network.EndpointSettings) container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:         id,
+			Name:       "/" + name,
+			State:      &container.State{Running: running},
+			HostConfig: &container.HostConfig{},
+		},
+		Config:          &container.Config{ExposedPorts: ports},
+		NetworkSettings: &container.NetworkSettings{Networks: networks},
+	}
+}
+
+func TestPlanConnectivity(t *testing.T) {
+	t.Parallel()
+
+	src := inspectWithNetworks("aaaaaaaaaaaaaaaa", "web", true, nil, map[string]*network.EndpointSettings{
+		"app":    {IPAddress: "10.0.0.2"},
+		"bridge": {IPAddress: "172.17.0.2"},
+	})
+	dst := inspectWithNetworks("bbbbbbbbbbbbbbbb", "db", true,
+		nat.PortSet{"5432/tcp": {}, "53/udp": {}},
+		map[string]*network.EndpointSettings{
+			"app":    {IPAddress: "10.0.0.3", Aliases: []string{"postgres", "bbbbbbbbbbbb"}, DNSNames: []string{"db", "postgres", "bbbbbbbbbbbb"}},
+			"bridge": {IPAddress: "172.17.0.3"},
+			"other":  {IPAddress: "10.1.0.3"},
+		})
+
+	report, probes := planConnectivity(src, dst)
+
+	wantShared := []SharedNetwork{
+		{Name: "app", TargetIP: "10.0.0.3", Names: []string{"db", "postgres"}},
+		{Name: "bridge", TargetIP: "172.17.0.3"},
+	}
+	if !reflect.DeepEqual(report.Shared, wantShared) {
+		t.Fatalf("Shared = %+v, want %+v", report.Shared, wantShared)
+	}
+	if !reflect.DeepEqual(report.Ports, []string{"5432"}) {
+		t.Fatalf("Ports = %v, want [5432]", report.Ports)
+	}
+
+	wantProbes := []probe{
+		{Kind: ProbeDNS, Host: "db"},
+		{Kind: ProbeDNS, Host: "postgres"},
+		{Kind: ProbeTCP, Host: "10.0.0.3", Port: "5432"},
+		{Kind: ProbeTCP, Host: "172.17.0.3", Port: "5432"},
+		{Kind: ProbeTCP, Host: "db", Port: "5432"},
+	}
+	if !reflect.DeepEqual(probes, wantProbes) {
+		t.Fatalf("probes = %+v, want %+v", probes, wantProbes)
+	}
+	if len(report.Problems) != 1 {
+		t.Fatalf("Problems = %v, want only the UDP note", report.Problems)
+	}
+}
+
+func TestPlanConnectivityProblems(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		src, dst   container.InspectResponse
+		wantProbes int
+		wantIssues int
+	}{
+		{
+			name:       "no shared network",
+			src:        inspectWithNetworks("a", "web", true, nil, map[string]*network.EndpointSettings{"front": {}}),
+			dst:        inspectWithNetworks("b", "db", true, nat.PortSet{"80/tcp": {}}, map[string]*network.EndpointSettings{"back": {}}),
+			wantIssues: 1,
+		},
+		{
+			name:       "default bridge only",
+			src:        inspectWithNetworks("a", "web", true, nil, map[string]*network.EndpointSettings{"bridge": {IPAddress: "172.17.0.2"}}),
+			dst:        inspectWithNetworks("b", "db", true, nat.PortSet{"80/tcp": {}}, map[string]*network.EndpointSettings{"bridge": {IPAddress: "172.17.0.3"}}),
+			wantProbes: 1,
+			wantIssues: 1,
+		},
+		{
+			name:       "stopped target without ports",
+			src:        inspectWithNetworks("a", "web", true, nil, map[string]*network.EndpointSettings{"app": {}}),
+			dst:        inspectWithNetworks("b", "db", false, nil, map[string]*network.EndpointSettings{"app": {}}),
+			wantProbes: 1,
+			wantIssues: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			report, probes := planConnectivity(tt.src, tt.dst)
+			if len(probes) != tt.wantProbes {
+				t.Fatalf("probes = %+v, want %d", probes, tt.wantProbes)
+			}
+			if len(report.Problems) != tt.wantIssues {
+				t.Fatalf("Problems = %v, want %d", report.Problems, tt.wantIssues)
+			}
+			if !report.Failed() {
+				t.Fatal("Failed() = false, want true")
+			}
+		})
+	}
+}
+
+func TestClassifyProbe(t *testing.T) {
+	t.Parallel()
+
+	dns := probe{Kind: ProbeDNS, Host: "db"}
+	tcp := probe{Kind: ProbeTCP, Host: "db", Port: "5432"}
+
+	tests := []struct {
+		name       string
+		probe      probe
+		exitCode   int
+		output     string
+		wantStatus string
+		wantTool   string
+		wantReason string
+	}{
+		{name: "getent ok", probe: dns, output: "tool=getent\n10.0.0.3        db\n", wantStatus: ProbePass, wantTool: "getent", wantReason: "resolves to 10.0.0.3"},
+		{name: "nslookup ok", probe: dns, output: "tool=nslookup\nServer: 127.0.0.11\nAddress: 127.0.0.11:53\n\nName: db\nAddress: 10.0.0.3\n", wantStatus: ProbePass, wantTool: "nslookup", wantReason: "resolves to 10.0.0.3"},
+		{name: "dns fail", probe: dns, exitCode: 2, output: "tool=getent\n", wantStatus: ProbeFail, wantTool: "getent", wantReason: "name did not resolve"},
+		{name: "no dns tool", probe: dns, exitCode: 127, output: "tool=none\n", wantStatus: ProbeSkipped, wantReason: "no DNS tool in source image (tried getent, nslookup, host)"},
+		{name: "no shell", probe: tcp, exitCode: 127, output: "exec: \"sh\": executable file not found in $PATH", wantStatus: ProbeSkipped, wantReason: "source image has no /bin/sh"},
+		{name: "tcp ok", probe: tcp, output: "tool=nc\n", wantStatus: ProbePass, wantTool: "nc", wantReason: "connected"},
+		{name: "tcp refused", probe: tcp, exitCode: 1, output: "tool=bash\nbash: connect: Connection refused\n", wantStatus: ProbeFail, wantTool: "bash", wantReason: "bash: connect: Connection refused"},
+		{name: "tcp timeout", probe: tcp, exitCode: 124, output: "tool=bash\n", wantStatus: ProbeFail, wantTool: "bash", wantReason: "connection timed out"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := classifyProbe(tt.probe, tt.exitCode, tt.output)
+			if got.Status != tt.wantStatus || got.Tool != tt.wantTool || got.Reason != tt.wantReason {
+				t.Fatalf("classifyProbe() = %+v, want status=%s tool=%s reason=%q", got, tt.wantStatus, tt.wantTool, tt.wantReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// execCapture runs cmd inside a running container and returns its combined
+// stdout/stderr and exit code.
+func (c *Client) execCapture(ctx context.Context, id string, cmd []string) (string, int, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, id, container.ExecOptions{
+		Cmd:          cmd,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("exec create: %w", err)
+	}
+
+	resp, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return "", 0, fmt.Errorf("exec attach: %w", err)
+	}
+	defer resp.Close()
+
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, resp.Reader); err != nil {
+		return out.String(), 0, fmt.Errorf("exec read: %w", err)
+	}
+
+	inspect, err := c.cli.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return out.String(), 0, fmt.Errorf("exec inspect: %w", err)
+	}
+	return out.String(), inspect.ExitCode, nil
+}
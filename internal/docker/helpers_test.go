@@ -1,10 +1,54 @@
 package docker
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
 	"testing"
 	"time"
+
+	"github.com/docker/docker/client"
 )
 
+// fakeDaemon answers Docker API requests with canned JSON bodies keyed by
+// method and path without the version prefix, e.g. "GET /containers/json".
+// Unknown requests get a 404 like a missing object.
+type fakeDaemon map[string]any
+
+var apiVersionPrefix = regexp.MustCompile(`^/v[0-9.]+`)
+
+func (d fakeDaemon) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + apiVersionPrefix.ReplaceAllString(req.URL.Path, "")
+	body, ok := d[key]
+	status := http.StatusOK
+	if !ok {
+		status = http.StatusNotFound
+		body = map[string]string{"message": "no such object: " + key}
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": {"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(data)),
+		Request:    req,
+	}, nil
+}
+
+// newFakeClient returns a Client whose requests are answered by d.
+func newFakeClient(t *testing.T, d fakeDaemon) *Client {
+	t.Helper()
+	cli, err := client.NewClientWithOpts(client.WithHTTPClient(&http.Client{Transport: d}), client.WithVersion("1.47"))
+	if err != nil {
+		t.Fatalf("create fake client: %v", err)
+	}
+	return &Client{cli: cli}
+}
+
 func TestShortImageID(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,48 @@
+package docker
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// InspectJSON returns the inspect response of a container, image, network
+// or volume decoded into generic JSON values (maps, slices, strings,
+// float64s, bools and nils), as the filter's path criteria walk it. kind is
+// "container", "image", "network" or "volume".
+func (c *Client) InspectJSON(kind, id string) (any, error) {
+	ctx, cancel := timeoutCtx(inspectTimeout)
+	defer cancel()
+
+	var data any
+	var err error
+	switch kind {
+	case "container":
+		data, err = c.cli.ContainerInspect(ctx, id)
+	case "image":
+		data, _, err = c.cli.ImageInspectWithRaw(ctx, id)
+	case "network":
+		data, err = c.cli.NetworkInspect(ctx, id, network.InspectOptions{})
+	case "volume":
+		data, err = c.cli.VolumeInspect(ctx, id)
+	default:
+		return nil, fmt.Errorf("cannot inspect %s", kind)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return toGenericJSON(data)
+}
+
+func toGenericJSON(v any) (any, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var out any
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
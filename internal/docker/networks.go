@@ -0,0 +1,240 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+// networkMembers groups containers by the full ID of every network they are
+// attached to. The container list does not report endpoint aliases; see
+// InspectNetworkMembers.
+func networkMembers(containers []container.Summary) map[string][]NetworkMember {
+	members := make(map[string][]NetworkMember)
+	for _, ctr := range containers {
+		if ctr.NetworkSettings == nil {
+			continue
+		}
+		name := "<none>"
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+		for _, ep := range ctr.NetworkSettings.Networks {
+			if ep == nil || ep.NetworkID == "" {
+				continue
+			}
+			members[ep.NetworkID] = append(members[ep.NetworkID], NetworkMember{
+				ContainerID: ctr.ID,
+				Name:        name,
+				State:       ctr.State,
+				IPv4:        formatCIDR(ep.IPAddress, ep.IPPrefixLen),
+				IPv6:        formatCIDR(ep.GlobalIPv6Address, ep.GlobalIPv6PrefixLen),
+			})
+		}
+	}
+	for id := range members {
+		sort.Slice(members[id], func(i, j int) bool {
+			return members[id][i].Name < members[id][j].Name
+		})
+	}
+	return members
+}
+
+// InspectNetworkMembers returns the members of net with the aliases of their
+// endpoints, which only container inspect reports.
+func (c *Client) InspectNetworkMembers(net NetworkInfo) ([]NetworkMember, error) {
+	members := make([]NetworkMember, len(net.Members))
+	for i, m := range net.Members {
+		ctx, cancel := timeoutCtx(inspectTimeout)
+		details, err := c.cli.ContainerInspect(ctx, m.ContainerID)
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("inspect %s: %w", m.Name, err)
+		}
+		members[i] = m
+		if details.NetworkSettings == nil {
+			continue
+		}
+		if ep := details.NetworkSettings.Networks[net.Name]; ep != nil {
+			members[i].Aliases = userAliases(ep.Aliases, m.ContainerID)
+		}
+	}
+	return members, nil
+}
+
+// ipamSummary extracts the configured subnets and gateways of a network.
+func ipamSummary(ipam network.IPAM) ([]string, []string) {
+	var subnets, gateways []string
+	for _, cfg := range ipam.Config {
+		if cfg.Subnet != "" {
+			subnets = append(subnets, cfg.Subnet)
+		}
+		if cfg.Gateway != "" {
+			gateways = append(gateways, cfg.Gateway)
+		}
+	}
+	return subnets, gateways
+}
+
+func formatCIDR(ip string, prefix int) string {
+	if ip == "" {
+		return ""
+	}
+	if prefix <= 0 {
+		return ip
+	}
+	return fmt.Sprintf("%s/%d", ip, prefix)
+}
+
+// NetworkSpec describes a network to create.
+type NetworkSpec struct {
+	Name       string
+	Driver     string
+	Subnet     string
+	Gateway    string
+	Internal   bool
+	Attachable bool
+	Labels     map[string]string
+	Options    map[string]string
+}
+
+// NetworkInput is the textual form of NetworkSpec as edited in the UI.
+type NetworkInput struct {
+	Name       string
+	Driver     string
+	Subnet     string
+	Gateway    string
+	Internal   bool
+	Attachable bool
+	Labels     string
+	Options    string
+}
+
+// Parse validates the input and converts it into a NetworkSpec.
+func (in NetworkInput) Parse() (NetworkSpec, error) {
+	var errs []error
+	spec := NetworkSpec{
+		Name:       strings.TrimSpace(in.Name),
+		Driver:     strings.TrimSpace(in.Driver),
+		Subnet:     strings.TrimSpace(in.Subnet),
+		Gateway:    strings.TrimSpace(in.Gateway),
+		Internal:   in.Internal,
+		Attachable: in.Attachable,
+	}
+
+	if spec.Name == "" {
+		errs = append(errs, errors.New("name: required"))
+	}
+
+	var subnet *net.IPNet
+	if spec.Subnet != "" {
+		_, parsed, err := net.ParseCIDR(spec.Subnet)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("subnet: invalid CIDR %q", spec.Subnet))
+		} else {
+			subnet = parsed
+		}
+	}
+	if spec.Gateway != "" {
+		gw := net.ParseIP(spec.Gateway)
+		switch {
+		case gw == nil:
+			errs = append(errs, fmt.Errorf("gateway: invalid IP %q", spec.Gateway))
+		case spec.Subnet == "":
+			errs = append(errs, errors.New("gateway: requires a subnet"))
+		case subnet != nil && !subnet.Contains(gw):
+			errs = append(errs, fmt.Errorf("gateway: %s is outside subnet %s", spec.Gateway, spec.Subnet))
+		}
+	}
+
+	labels, err := parseKeyValues(in.Labels)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("labels: %w", err))
+	}
+	spec.Labels = labels
+
+	options, err := parseKeyValues(in.Options)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("options: %w", err))
+	}
+	spec.Options = options
+
+	return spec, errors.Join(errs...)
+}
+
+func (s NetworkSpec) createOptions() network.CreateOptions {
+	opts := network.CreateOptions{
+		Driver:     s.Driver,
+		Internal:   s.Internal,
+		Attachable: s.Attachable,
+		Labels:     s.Labels,
+		Options:    s.Options,
+	}
+	if s.Subnet != "" {
+		opts.IPAM = &network.IPAM{
+			Config: []network.IPAMConfig{{Subnet: s.Subnet, Gateway: s.Gateway}},
+		}
+	}
+	return opts
+}
+
+// CreateNetwork creates a network from spec and returns its ID.
+func (c *Client) CreateNetwork(spec NetworkSpec) (string, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	resp, err := c.cli.NetworkCreate(ctx, spec.Name, spec.createOptions())
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// EndpointInput holds the optional settings used when connecting a container
+// to a network. Aliases is a comma separated list.
+type EndpointInput struct {
+	Aliases string
+	IP      string
+}
+
+// Parse validates the input and converts it into endpoint settings.
+func (in EndpointInput) Parse() (*network.EndpointSettings, error) {
+	settings := &network.EndpointSettings{Aliases: splitList(in.Aliases)}
+	ip := strings.TrimSpace(in.IP)
+	if ip == "" {
+		return settings, nil
+	}
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return nil, fmt.Errorf("ip: invalid address %q", ip)
+	}
+	if parsed.To4() != nil {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv4Address: ip}
+	} else {
+		settings.IPAMConfig = &network.EndpointIPAMConfig{IPv6Address: ip}
+	}
+	return settings, nil
+}
+
+// ConnectNetwork attaches a container to a network.
+func (c *Client) ConnectNetwork(networkID, containerID string, endpoint EndpointInput) error {
+	settings, err := endpoint.Parse()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	return c.cli.NetworkConnect(ctx, networkID, containerID, settings)
+}
+
+// DisconnectNetwork detaches a container from a network.
+func (c *Client) DisconnectNetwork(networkID, containerID string, force bool) error {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	return c.cli.NetworkDisconnect(ctx, networkID, containerID, force)
+}
@@ -0,0 +1,212 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+)
+
+func TestNetworkMembers(t *testing.T) {
+	t.Parallel()
+
+	containers := []container.Summary{
+		{
+			ID:    "bbbbbbbbbbbbbbbb",
+			Names: []string{"/web"},
+			State: "running",
+			NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+				"frontend": {NetworkID: "net-front", IPAddress: "172.18.0.3", IPPrefixLen: 16},
+				"backend":  {NetworkID: "net-back", IPAddress: "172.19.0.2", IPPrefixLen: 16, GlobalIPv6Address: "fd00::2", GlobalIPv6PrefixLen: 64},
+			}},
+		},
+		{
+			ID:    "aaaaaaaaaaaaaaaa",
+			Names: []string{"/api"},
+			State: "exited",
+			NetworkSettings: &container.NetworkSettingsSummary{Networks: map[string]*network.EndpointSettings{
+				"frontend": {NetworkID: "net-front"},
+			}},
+		},
+		{ID: "cccccccccccccccc", Names: []string{"/orphan"}},
+	}
+
+	members := networkMembers(containers)
+
+	front := members["net-front"]
+	if len(front) != 2 || front[0].Name != "api" || front[1].Name != "web" {
+		t.Fatalf("frontend members = %+v, want api and web sorted by name", front)
+	}
+	if front[1].IPv4 != "172.18.0.3/16" {
+		t.Fatalf("web endpoint = %+v", front[1])
+	}
+	if front[0].IPv4 != "" {
+		t.Fatalf("stopped container should have no address, got %q", front[0].IPv4)
+	}
+
+	back := members["net-back"]
+	if len(back) != 1 || back[0].IPv6 != "fd00::2/64" {
+		t.Fatalf("backend members = %+v", back)
+	}
+}
+
+func TestInspectNetworkMembers(t *testing.T) {
+	t.Parallel()
+
+	c := newFakeClient(t, fakeDaemon{
+		"GET /containers/bbbbbbbbbbbbbbbb/json": container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "bbbbbbbbbbbbbbbb"},
+			NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+				"frontend": {NetworkID: "net-front", Aliases: []string{"web", "bbbbbbbbbbbb"}},
+			}},
+		},
+		"GET /containers/aaaaaaaaaaaaaaaa/json": container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "aaaaaaaaaaaaaaaa"},
+			NetworkSettings: &container.NetworkSettings{Networks: map[string]*network.EndpointSettings{
+				"frontend": {NetworkID: "net-front"},
+			}},
+		},
+	})
+
+	net := NetworkInfo{Name: "frontend", Members: []NetworkMember{
+		{ContainerID: "aaaaaaaaaaaaaaaa", Name: "api"},
+		{ContainerID: "bbbbbbbbbbbbbbbb", Name: "web"},
+	}}
+	members, err := c.InspectNetworkMembers(net)
+	if err != nil {
+		t.Fatalf("InspectNetworkMembers() error = %v", err)
+	}
+	if len(members[0].Aliases) != 0 || !reflect.DeepEqual(members[1].Aliases, []string{"web"}) {
+		t.Fatalf("aliases = %v and %v, want none and [web]", members[0].Aliases, members[1].Aliases)
+	}
+	if net.Members[1].Aliases != nil {
+		t.Fatalf("InspectNetworkMembers must not modify the listed members")
+	}
+
+	net.Members = append(net.Members, NetworkMember{ContainerID: "gone", Name: "gone"})
+	if _, err := c.InspectNetworkMembers(net); err == nil {
+		t.Fatalf("expected error for a container that cannot be inspected")
+	}
+}
+
+func TestIPAMSummary(t *testing.T) {
+	t.Parallel()
+
+	subnets, gateways := ipamSummary(network.IPAM{Config: []network.IPAMConfig{
+		{Subnet: "172.18.0.0/16", Gateway: "172.18.0.1"},
+		{Subnet: "fd00::/64"},
+	}})
+	if !reflect.DeepEqual(subnets, []string{"172.18.0.0/16", "fd00::/64"}) {
+		t.Fatalf("subnets = %v", subnets)
+	}
+	if !reflect.DeepEqual(gateways, []string{"172.18.0.1"}) {
+		t.Fatalf("gateways = %v", gateways)
+	}
+}
+
+func TestNetworkInputParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   NetworkInput
+		wantErr bool
+	}{
+		{name: "minimal", input: NetworkInput{Name: "app"}},
+		{name: "subnet and gateway", input: NetworkInput{Name: "app", Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}},
+		{name: "ipv6", input: NetworkInput{Name: "app", Subnet: "fd00::/64", Gateway: "fd00::1"}},
+		{name: "missing name", input: NetworkInput{Subnet: "10.10.0.0/24"}, wantErr: true},
+		{name: "bad subnet", input: NetworkInput{Name: "app", Subnet: "10.10.0.0"}, wantErr: true},
+		{name: "gateway outside subnet", input: NetworkInput{Name: "app", Subnet: "10.10.0.0/24", Gateway: "10.10.1.1"}, wantErr: true},
+		{name: "gateway without subnet", input: NetworkInput{Name: "app", Gateway: "10.10.0.1"}, wantErr: true},
+		{name: "bad label", input: NetworkInput{Name: "app", Labels: "=x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := tt.input.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNetworkSpecCreateOptions(t *testing.T) {
+	t.Parallel()
+
+	spec, err := NetworkInput{
+		Name:     "app",
+		Driver:   "bridge",
+		Subnet:   "10.10.0.0/24",
+		Gateway:  "10.10.0.1",
+		Internal: true,
+		Labels:   "team=web, env=dev",
+		Options:  "com.docker.network.bridge.name=br-app",
+	}.Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	opts := spec.createOptions()
+	if opts.Driver != "bridge" || !opts.Internal || opts.Attachable {
+		t.Fatalf("createOptions() = %+v", opts)
+	}
+	if opts.IPAM == nil || !reflect.DeepEqual(opts.IPAM.Config, []network.IPAMConfig{{Subnet: "10.10.0.0/24", Gateway: "10.10.0.1"}}) {
+		t.Fatalf("IPAM = %+v", opts.IPAM)
+	}
+	if !reflect.DeepEqual(opts.Labels, map[string]string{"team": "web", "env": "dev"}) {
+		t.Fatalf("Labels = %v", opts.Labels)
+	}
+	if opts.Options["com.docker.network.bridge.name"] != "br-app" {
+		t.Fatalf("Options = %v", opts.Options)
+	}
+
+	if (NetworkSpec{Name: "plain"}).createOptions().IPAM != nil {
+		t.Fatal("IPAM should be left to the daemon when no subnet is given")
+	}
+}
+
+func TestEndpointInputParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   EndpointInput
+		want    *network.EndpointSettings
+		wantErr bool
+	}{
+		{name: "empty", input: EndpointInput{}, want: &network.EndpointSettings{}},
+		{
+			name:  "aliases and ipv4",
+			input: EndpointInput{Aliases: "db, primary", IP: "10.10.0.5"},
+			want: &network.EndpointSettings{
+				Aliases:    []string{"db", "primary"},
+				IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "10.10.0.5"},
+			},
+		},
+		{
+			name:  "ipv6",
+			input: EndpointInput{IP: "fd00::5"},
+			want:  &network.EndpointSettings{IPAMConfig: &network.EndpointIPAMConfig{IPv6Address: "fd00::5"}},
+		},
+		{name: "invalid ip", input: EndpointInput{IP: "10.10.0"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.input.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
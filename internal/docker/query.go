@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// ContainerStage is how much of a container is known while it is listed.
+type ContainerStage int
+
+const (
+	// StageListed covers the list call: names, image, command, state,
+	// status, ports, labels, networks and IPs, volumes, sizes, creation time
+	// and whether the image is outdated.
+	StageListed ContainerStage = iota
+	// StageInspected adds the restart count, exit code, OOM kill, health and
+	// start and finish times. Stats and crash loops are not known yet.
+	StageInspected
+)
+
+// ContainerQuery narrows QueryContainers. Filters are sent to the daemon;
+// Keep, when set, drops containers that cannot match once a stage is known
+// so they are never inspected or asked for stats. Keep must not reject a
+// container on fields its stage does not cover. Size asks the daemon for
+// container sizes, which makes listing slower.
+type ContainerQuery struct {
+	Filters filters.Args
+	Keep    func(info ContainerInfo, stage ContainerStage) bool
+	Size    bool
+}
+
+func (q ContainerQuery) keep(containers []ContainerInfo, stage ContainerStage) []ContainerInfo {
+	if q.Keep == nil {
+		return containers
+	}
+	kept := containers[:0]
+	for _, c := range containers {
+		if q.Keep(c, stage) {
+			kept = append(kept, c)
+		}
+	}
+	return kept
+}
+
+// isDanglingTags reports whether an image's repository tags are only the
+// "<none>:<none>" placeholder.
+func isDanglingTags(tags []string) bool {
+	for _, tag := range tags {
+		if tag != "<none>:<none>" {
+			return false
+		}
+	}
+	return true
+}
+
+// CountContainers returns how many containers exist, for totals of a view
+// whose rows were listed under filters.
+func (c *Client) CountContainers() (int, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{All: true})
+	return len(containers), err
+}
+
+// CountImages returns how many images exist.
+func (c *Client) CountImages() (int, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	images, err := c.cli.ImageList(ctx, image.ListOptions{All: true})
+	return len(images), err
+}
+
+// CountNetworks returns how many networks exist.
+func (c *Client) CountNetworks() (int, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	networks, err := c.cli.NetworkList(ctx, network.ListOptions{})
+	return len(networks), err
+}
+
+// CountVolumes returns how many volumes exist.
+func (c *Client) CountVolumes() (int, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+	volumes, err := c.cli.VolumeList(ctx, volume.ListOptions{})
+	return len(volumes.Volumes), err
+}
@@ -0,0 +1,38 @@
+package docker
+
+import "testing"
+
+func TestContainerQueryKeep(t *testing.T) {
+	t.Parallel()
+
+	containers := []ContainerInfo{{Name: "a", State: "running"}, {Name: "b", State: "exited"}, {Name: "c", State: "running"}}
+	q := ContainerQuery{Keep: func(c ContainerInfo, stage ContainerStage) bool {
+		return stage != StageListed || c.State == "running"
+	}}
+
+	kept := q.keep(append([]ContainerInfo(nil), containers...), StageListed)
+	if len(kept) != 2 || kept[0].Name != "a" || kept[1].Name != "c" {
+		t.Fatalf("keep(StageListed) = %+v, want a and c", kept)
+	}
+	if all := (ContainerQuery{}).keep(containers, StageListed); len(all) != 3 {
+		t.Fatalf("keep without Keep = %d containers, want 3", len(all))
+	}
+}
+
+func TestIsDanglingTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		tags []string
+		want bool
+	}{
+		{[]string{"<none>:<none>"}, true},
+		{[]string{"nginx:latest"}, false},
+		{[]string{"<none>:<none>", "nginx:latest"}, false},
+	}
+	for _, tt := range tests {
+		if got := isDanglingTags(tt.tags); got != tt.want {
+			t.Fatalf("isDanglingTags(%v) = %v, want %v", tt.tags, got, tt.want)
+		}
+	}
+}
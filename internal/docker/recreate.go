@@ -0,0 +1,360 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+)
+
+const lifecycleTimeout = 60 * time.Second
+
+// RecreateOptions controls how a container is recreated.
+type RecreateOptions struct {
+	// Image replaces the container's image reference when non-empty.
+	Image string
+	// RemoveOld deletes the previous container once the new one is running.
+	RemoveOld bool
+}
+
+// RecreateResult describes what happened during a recreate.
+type RecreateResult struct {
+	Name       string
+	OldID      string
+	NewID      string
+	Image      string
+	BackupName string // name of the old container when it was kept
+	RolledBack bool
+}
+
+// createSpec bundles the three configs ContainerCreate needs.
+type createSpec struct {
+	Config     *container.Config
+	HostConfig *container.HostConfig
+	Networking *network.NetworkingConfig
+}
+
+// RecreateContainer replaces a container with a fresh one built from the same
+// configuration. The old container is stopped and renamed out of the way
+// before the new one takes its name; if the new container fails to be created
+// or started, the old one is restored under its original name and restarted.
+func (c *Client) RecreateContainer(id string, opts RecreateOptions) (RecreateResult, error) {
+	ctx, cancel := timeoutCtx(lifecycleTimeout)
+	defer cancel()
+
+	details, err := c.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return RecreateResult{}, fmt.Errorf("inspect: %w", err)
+	}
+	spec, err := buildCreateSpec(details, opts.Image, true)
+	if err != nil {
+		return RecreateResult{}, err
+	}
+	// Inspect merges the old image's defaults into Config; drop them so the
+	// new container picks up the defaults of whatever image it runs now.
+	if img, err := c.cli.ImageInspect(ctx, details.Image); err == nil && img.Config != nil {
+		stripImageDefaults(spec.Config, img.Config)
+	}
+
+	name := strings.TrimPrefix(details.Name, "/")
+	result := RecreateResult{
+		Name:       name,
+		OldID:      details.ID,
+		Image:      spec.Config.Image,
+		BackupName: fmt.Sprintf("%s_old_%d", name, time.Now().Unix()),
+	}
+	wasRunning := details.State != nil && details.State.Running
+
+	if wasRunning {
+		stopTimeout := 10
+		if err := c.cli.ContainerStop(ctx, details.ID, container.StopOptions{Timeout: &stopTimeout}); err != nil {
+			return result, fmt.Errorf("stop old container: %w", err)
+		}
+	}
+	if err := c.cli.ContainerRename(ctx, details.ID, result.BackupName); err != nil {
+		result.RolledBack = true
+		return result, errors.Join(fmt.Errorf("rename old container: %w", err), c.restoreOld(details.ID, "", wasRunning))
+	}
+
+	created, err := c.cli.ContainerCreate(ctx, spec.Config, spec.HostConfig, spec.Networking, nil, name)
+	if err != nil {
+		result.RolledBack = true
+		return result, errors.Join(fmt.Errorf("create new container: %w", err), c.restoreOld(details.ID, name, wasRunning))
+	}
+	result.NewID = created.ID
+
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		result.RolledBack = true
+		rmErr := c.cli.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true})
+		if rmErr != nil {
+			rmErr = fmt.Errorf("remove failed container: %w", rmErr)
+		}
+		return result, errors.Join(fmt.Errorf("start new container: %w", err), rmErr, c.restoreOld(details.ID, name, wasRunning))
+	}
+
+	if opts.RemoveOld {
+		if err := c.cli.ContainerRemove(ctx, details.ID, container.RemoveOptions{}); err != nil {
+			return result, fmt.Errorf("new container is running but removing the old one failed: %w", err)
+		}
+		result.BackupName = ""
+	}
+	return result, nil
+}
+
+// restoreOld renames the old container back (when name is set) and restarts
+// it if it was running before the recreate began.
+func (c *Client) restoreOld(id, name string, start bool) error {
+	ctx, cancel := timeoutCtx(lifecycleTimeout)
+	defer cancel()
+
+	if name != "" {
+		if err := c.cli.ContainerRename(ctx, id, name); err != nil {
+			return fmt.Errorf("rollback rename: %w", err)
+		}
+	}
+	if start {
+		if err := c.cli.ContainerStart(ctx, id, container.StartOptions{}); err != nil {
+			return fmt.Errorf("rollback start: %w", err)
+		}
+	}
+	return nil
+}
+
+// CloneContainer creates a copy of a container under a new name, optionally
+// starting it. A clone that cannot start is removed again.
+func (c *Client) CloneContainer(id, name string, start bool) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", errors.New("clone name is required")
+	}
+
+	ctx, cancel := timeoutCtx(lifecycleTimeout)
+	defer cancel()
+
+	details, err := c.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("inspect: %w", err)
+	}
+	spec, err := buildCreateSpec(details, "", false)
+	if err != nil {
+		return "", err
+	}
+
+	created, err := c.cli.ContainerCreate(ctx, spec.Config, spec.HostConfig, spec.Networking, nil, name)
+	if err != nil {
+		return "", fmt.Errorf("create clone: %w", err)
+	}
+	if !start {
+		return created.ID, nil
+	}
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		rmErr := c.cli.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true})
+		return "", errors.Join(fmt.Errorf("start clone: %w", err), rmErr)
+	}
+	return created.ID, nil
+}
+
+// buildCreateSpec derives create-time configuration from an inspect response.
+// Operational data (endpoint IDs, assigned IPs, generated hostnames) is
+// dropped. When keepIdentity is false, MAC addresses, anonymous volumes,
+// static IPs, network aliases and host ports are not carried over so a clone
+// can coexist with its source; its ports are published on ports the daemon
+// picks. Keeping the identity of an auto-removed container is refused: the
+// daemon deletes it once it is stopped, leaving nothing to roll back to.
+func buildCreateSpec(details container.InspectResponse, image string, keepIdentity bool) (createSpec, error) {
+	if details.ContainerJSONBase == nil || details.Config == nil || details.HostConfig == nil {
+		return createSpec{}, errors.New("inspect response is missing container configuration")
+	}
+	if keepIdentity && details.HostConfig.AutoRemove {
+		return createSpec{}, errors.New("container is removed when stopped (--rm), so it cannot be recreated; clone it instead")
+	}
+
+	cfg := *details.Config
+	cfg.Labels = copyStringMap(details.Config.Labels)
+	if image != "" {
+		cfg.Image = image
+	}
+	// Docker defaults the hostname to the short container ID; keep explicit
+	// hostnames only.
+	if len(details.ID) >= 12 && cfg.Hostname == details.ID[:12] {
+		cfg.Hostname = ""
+	}
+	if !keepIdentity {
+		cfg.MacAddress = ""
+	}
+
+	hostCfg := *details.HostConfig
+	hostCfg.Mounts = append([]mount.Mount(nil), details.HostConfig.Mounts...)
+	if keepIdentity {
+		hostCfg.Mounts = append(hostCfg.Mounts, anonymousVolumeMounts(details)...)
+	} else {
+		hostCfg.PortBindings = withoutHostPorts(details.HostConfig.PortBindings)
+	}
+
+	spec := createSpec{Config: &cfg, HostConfig: &hostCfg}
+
+	mode := hostCfg.NetworkMode
+	if details.NetworkSettings == nil || mode.IsHost() || mode.IsNone() || mode.IsContainer() {
+		return spec, nil
+	}
+
+	endpoints := make(map[string]*network.EndpointSettings, len(details.NetworkSettings.Networks))
+	for netName, ep := range details.NetworkSettings.Networks {
+		if ep == nil {
+			continue
+		}
+		settings := &network.EndpointSettings{
+			Links:      append([]string(nil), ep.Links...),
+			DriverOpts: copyStringMap(ep.DriverOpts),
+			GwPriority: ep.GwPriority,
+		}
+		if keepIdentity {
+			settings.Aliases = userAliases(ep.Aliases, details.ID)
+			if ep.IPAMConfig != nil {
+				ipam := *ep.IPAMConfig
+				settings.IPAMConfig = &ipam
+			}
+		}
+		endpoints[netName] = settings
+	}
+	if len(endpoints) > 0 {
+		spec.Networking = &network.NetworkingConfig{EndpointsConfig: endpoints}
+	}
+	return spec, nil
+}
+
+// stripImageDefaults removes values from cfg that were inherited from the
+// image rather than set explicitly when the container was created.
+func stripImageDefaults(cfg *container.Config, img *dockerspec.DockerOCIImageConfig) {
+	if slices.Equal([]string(cfg.Cmd), img.Cmd) {
+		cfg.Cmd = nil
+	}
+	if slices.Equal([]string(cfg.Entrypoint), img.Entrypoint) {
+		cfg.Entrypoint = nil
+	}
+	if cfg.WorkingDir == img.WorkingDir {
+		cfg.WorkingDir = ""
+	}
+	if cfg.User == img.User {
+		cfg.User = ""
+	}
+	if cfg.StopSignal == img.StopSignal {
+		cfg.StopSignal = ""
+	}
+
+	if len(img.Env) > 0 {
+		imageEnv := make(map[string]struct{}, len(img.Env))
+		for _, kv := range img.Env {
+			imageEnv[kv] = struct{}{}
+		}
+		env := cfg.Env[:0:0]
+		for _, kv := range cfg.Env {
+			if _, ok := imageEnv[kv]; !ok {
+				env = append(env, kv)
+			}
+		}
+		cfg.Env = env
+	}
+	for key, value := range img.Labels {
+		if cfg.Labels[key] == value {
+			delete(cfg.Labels, key)
+		}
+	}
+	if len(img.ExposedPorts) > 0 && cfg.ExposedPorts != nil {
+		ports := make(nat.PortSet, len(cfg.ExposedPorts))
+		for port := range cfg.ExposedPorts {
+			if _, ok := img.ExposedPorts[string(port)]; !ok {
+				ports[port] = struct{}{}
+			}
+		}
+		cfg.ExposedPorts = ports
+	}
+	if len(img.Volumes) > 0 && cfg.Volumes != nil {
+		volumes := make(map[string]struct{}, len(cfg.Volumes))
+		for path := range cfg.Volumes {
+			if _, ok := img.Volumes[path]; !ok {
+				volumes[path] = struct{}{}
+			}
+		}
+		cfg.Volumes = volumes
+	}
+}
+
+// anonymousVolumeMounts re-attaches volumes that were created implicitly from
+// the image's VOLUME declarations so a recreate does not lose their data.
+func anonymousVolumeMounts(details container.InspectResponse) []mount.Mount {
+	used := make(map[string]struct{})
+	for _, bind := range details.HostConfig.Binds {
+		parts := strings.Split(bind, ":")
+		if len(parts) >= 2 {
+			used[parts[1]] = struct{}{}
+		}
+	}
+	for _, m := range details.HostConfig.Mounts {
+		used[m.Target] = struct{}{}
+	}
+
+	var mounts []mount.Mount
+	for _, mp := range details.Mounts {
+		if mp.Type != mount.TypeVolume || mp.Name == "" {
+			continue
+		}
+		if _, ok := used[mp.Destination]; ok {
+			continue
+		}
+		mounts = append(mounts, mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   mp.Name,
+			Target:   mp.Destination,
+			ReadOnly: !mp.RW,
+		})
+	}
+	return mounts
+}
+
+// withoutHostPorts copies port bindings, leaving the host port of each empty
+// so the daemon assigns a free one.
+func withoutHostPorts(bindings nat.PortMap) nat.PortMap {
+	if bindings == nil {
+		return nil
+	}
+	out := make(nat.PortMap, len(bindings))
+	for port, binds := range bindings {
+		cleared := make([]nat.PortBinding, len(binds))
+		for i, b := range binds {
+			cleared[i] = nat.PortBinding{HostIP: b.HostIP}
+		}
+		out[port] = cleared
+	}
+	return out
+}
+
+// userAliases drops the short container ID alias the daemon adds on its own.
+func userAliases(aliases []string, id string) []string {
+	var out []string
+	for _, alias := range aliases {
+		if len(id) >= 12 && alias == id[:12] {
+			continue
+		}
+		out = append(out, alias)
+	}
+	return out
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
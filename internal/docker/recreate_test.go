@@ -0,0 +1,196 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	dockerspec "github.com/moby/docker-image-spec/specs-go/v1"
+	ocispec "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+const testContainerID = "0123456789abcdef0123456789abcdef"
+
+func testInspect() container.InspectResponse {
+	return container.InspectResponse{
+		ContainerJSONBase: &container.ContainerJSONBase{
+			ID:   testContainerID,
+			Name: "/web",
+			HostConfig: &container.HostConfig{
+				NetworkMode: "appnet",
+				Binds:       []string{"/srv/data:/data"},
+				PortBindings: nat.PortMap{
+					"80/tcp": {{HostIP: "127.0.0.1", HostPort: "8080"}},
+				},
+			},
+		},
+		Config: &container.Config{
+			Hostname:   testContainerID[:12],
+			Image:      "nginx:1.25",
+			MacAddress: "02:42:ac:11:00:02",
+			Labels:     map[string]string{"app": "web"},
+		},
+		Mounts: []container.MountPoint{
+			{Type: mount.TypeBind, Source: "/srv/data", Destination: "/data", RW: true},
+			{Type: mount.TypeVolume, Name: "abc123", Destination: "/cache", RW: true},
+		},
+		NetworkSettings: &container.NetworkSettings{
+			Networks: map[string]*network.EndpointSettings{
+				"appnet": {
+					Aliases:    []string{"web", testContainerID[:12]},
+					IPAMConfig: &network.EndpointIPAMConfig{IPv4Address: "172.18.0.5"},
+					IPAddress:  "172.18.0.5",
+					EndpointID: "ep",
+					MacAddress: "02:42:ac:12:00:05",
+				},
+			},
+		},
+	}
+}
+
+func TestBuildCreateSpecRecreate(t *testing.T) {
+	t.Parallel()
+
+	spec, err := buildCreateSpec(testInspect(), "nginx:1.27", true)
+	if err != nil {
+		t.Fatalf("buildCreateSpec() unexpected error: %v", err)
+	}
+	if spec.Config.Image != "nginx:1.27" {
+		t.Fatalf("image = %q, want nginx:1.27", spec.Config.Image)
+	}
+	if spec.Config.Hostname != "" {
+		t.Fatalf("generated hostname should be dropped, got %q", spec.Config.Hostname)
+	}
+	if spec.Config.MacAddress == "" {
+		t.Fatalf("recreate should keep configured MAC address")
+	}
+
+	wantMounts := []mount.Mount{{Type: mount.TypeVolume, Source: "abc123", Target: "/cache"}}
+	if !reflect.DeepEqual(spec.HostConfig.Mounts, wantMounts) {
+		t.Fatalf("mounts = %+v, want %+v", spec.HostConfig.Mounts, wantMounts)
+	}
+
+	ep := spec.Networking.EndpointsConfig["appnet"]
+	if ep == nil {
+		t.Fatalf("expected appnet endpoint")
+	}
+	if !reflect.DeepEqual(ep.Aliases, []string{"web"}) {
+		t.Fatalf("aliases = %v, want [web]", ep.Aliases)
+	}
+	if ep.IPAddress != "" || ep.EndpointID != "" || ep.MacAddress != "" {
+		t.Fatalf("operational endpoint data should be dropped: %+v", ep)
+	}
+	if ep.IPAMConfig == nil || ep.IPAMConfig.IPv4Address != "172.18.0.5" {
+		t.Fatalf("recreate should keep the static IP, got %+v", ep.IPAMConfig)
+	}
+	if got := spec.HostConfig.PortBindings["80/tcp"]; len(got) != 1 || got[0].HostPort != "8080" {
+		t.Fatalf("recreate should keep host ports, got %+v", got)
+	}
+}
+
+func TestBuildCreateSpecAutoRemove(t *testing.T) {
+	t.Parallel()
+
+	details := testInspect()
+	details.HostConfig.AutoRemove = true
+	if _, err := buildCreateSpec(details, "", true); err == nil {
+		t.Fatalf("expected recreate of an auto-removed container to be refused")
+	}
+	if _, err := buildCreateSpec(details, "", false); err != nil {
+		t.Fatalf("clone of an auto-removed container failed: %v", err)
+	}
+}
+
+func TestBuildCreateSpecClone(t *testing.T) {
+	t.Parallel()
+
+	details := testInspect()
+	spec, err := buildCreateSpec(details, "", false)
+	if err != nil {
+		t.Fatalf("buildCreateSpec() unexpected error: %v", err)
+	}
+	if spec.Config.Image != "nginx:1.25" {
+		t.Fatalf("image = %q, want nginx:1.25", spec.Config.Image)
+	}
+	if spec.Config.MacAddress != "" {
+		t.Fatalf("clone should not reuse MAC address")
+	}
+	if len(spec.HostConfig.Mounts) != 0 {
+		t.Fatalf("clone should not share anonymous volumes: %+v", spec.HostConfig.Mounts)
+	}
+	wantPorts := nat.PortMap{"80/tcp": {{HostIP: "127.0.0.1"}}}
+	if !reflect.DeepEqual(spec.HostConfig.PortBindings, wantPorts) {
+		t.Fatalf("clone ports = %+v, want %+v", spec.HostConfig.PortBindings, wantPorts)
+	}
+	if details.HostConfig.PortBindings["80/tcp"][0].HostPort != "8080" {
+		t.Fatalf("clone port bindings must not alias the inspect response")
+	}
+	ep := spec.Networking.EndpointsConfig["appnet"]
+	if ep == nil || ep.IPAMConfig != nil || len(ep.Aliases) != 0 {
+		t.Fatalf("clone should not reuse static IPs or aliases: %+v", ep)
+	}
+
+	spec.Config.Labels["app"] = "changed"
+	if details.Config.Labels["app"] != "web" {
+		t.Fatalf("spec labels must not alias the inspect response")
+	}
+}
+
+func TestBuildCreateSpecHostNetwork(t *testing.T) {
+	t.Parallel()
+
+	details := testInspect()
+	details.HostConfig.NetworkMode = "host"
+	spec, err := buildCreateSpec(details, "", true)
+	if err != nil {
+		t.Fatalf("buildCreateSpec() unexpected error: %v", err)
+	}
+	if spec.Networking != nil {
+		t.Fatalf("host networking should not carry endpoint config")
+	}
+
+	if _, err := buildCreateSpec(container.InspectResponse{}, "", true); err == nil {
+		t.Fatalf("expected error for empty inspect response")
+	}
+}
+
+func TestStripImageDefaults(t *testing.T) {
+	t.Parallel()
+
+	cfg := &container.Config{
+		Cmd:          []string{"nginx", "-g", "daemon off;"},
+		Entrypoint:   []string{"/docker-entrypoint.sh"},
+		WorkingDir:   "/app",
+		Env:          []string{"PATH=/usr/bin", "NGINX_VERSION=1.25", "MODE=prod"},
+		Labels:       map[string]string{"maintainer": "nginx", "app": "web"},
+		ExposedPorts: nat.PortSet{"80/tcp": {}, "9000/tcp": {}},
+	}
+	img := &dockerspec.DockerOCIImageConfig{ImageConfig: ocispec.ImageConfig{
+		Cmd:          []string{"nginx", "-g", "daemon off;"},
+		Entrypoint:   []string{"/docker-entrypoint.sh"},
+		Env:          []string{"PATH=/usr/bin", "NGINX_VERSION=1.25"},
+		Labels:       map[string]string{"maintainer": "nginx"},
+		ExposedPorts: map[string]struct{}{"80/tcp": {}},
+	}}
+
+	stripImageDefaults(cfg, img)
+
+	if cfg.Cmd != nil || cfg.Entrypoint != nil {
+		t.Fatalf("inherited cmd/entrypoint should be dropped: %v %v", cfg.Cmd, cfg.Entrypoint)
+	}
+	if cfg.WorkingDir != "/app" {
+		t.Fatalf("explicit working dir should be kept, got %q", cfg.WorkingDir)
+	}
+	if !reflect.DeepEqual(cfg.Env, []string{"MODE=prod"}) {
+		t.Fatalf("env = %v, want [MODE=prod]", cfg.Env)
+	}
+	if !reflect.DeepEqual(cfg.Labels, map[string]string{"app": "web"}) {
+		t.Fatalf("labels = %v", cfg.Labels)
+	}
+	if !reflect.DeepEqual(cfg.ExposedPorts, nat.PortSet{"9000/tcp": {}}) {
+		t.Fatalf("exposed ports = %v", cfg.ExposedPorts)
+	}
+}
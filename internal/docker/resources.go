@@ -0,0 +1,396 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/go-units"
+)
+
+const (
+	minMemoryBytes = 6 * 1024 * 1024 // daemon rejects limits below 6MiB
+	minCPUQuota    = 1000
+	minCPUPeriod   = 1000
+	maxCPUPeriod   = 1000000
+)
+
+// RestartPolicies lists the restart policy names accepted by the daemon.
+var RestartPolicies = []string{
+	string(container.RestartPolicyDisabled),
+	string(container.RestartPolicyAlways),
+	string(container.RestartPolicyOnFailure),
+	string(container.RestartPolicyUnlessStopped),
+}
+
+var cpusetPattern = regexp.MustCompile(`^\d+(-\d+)?(,\d+(-\d+)?)*$`)
+
+// ResourceSettings holds the subset of a container's HostConfig that can be
+// changed in place with ContainerUpdate. Zero values mean "unlimited".
+type ResourceSettings struct {
+	Memory        int64  `json:"memory,omitempty"`
+	MemorySwap    int64  `json:"memorySwap,omitempty"`
+	CPUShares     int64  `json:"cpuShares,omitempty"`
+	CPUQuota      int64  `json:"cpuQuota,omitempty"`
+	CPUPeriod     int64  `json:"cpuPeriod,omitempty"`
+	CpusetCpus    string `json:"cpusetCpus,omitempty"`
+	PidsLimit     int64  `json:"pidsLimit,omitempty"`
+	RestartPolicy string `json:"restartPolicy,omitempty"`
+	MaxRetries    int    `json:"maxRetries,omitempty"`
+}
+
+// ResourceInput is the textual form of ResourceSettings as edited in the UI.
+type ResourceInput struct {
+	Memory        string
+	MemorySwap    string
+	CPUShares     string
+	CPUQuota      string
+	CPUPeriod     string
+	CpusetCpus    string
+	PidsLimit     string
+	RestartPolicy string
+	MaxRetries    string
+}
+
+// Input renders the settings as editable text, leaving unlimited values empty.
+func (s ResourceSettings) Input() ResourceInput {
+	return ResourceInput{
+		Memory:        formatMemoryInput(s.Memory),
+		MemorySwap:    formatMemoryInput(s.MemorySwap),
+		CPUShares:     formatIntInput(s.CPUShares),
+		CPUQuota:      formatIntInput(s.CPUQuota),
+		CPUPeriod:     formatIntInput(s.CPUPeriod),
+		CpusetCpus:    s.CpusetCpus,
+		PidsLimit:     formatIntInput(s.PidsLimit),
+		RestartPolicy: s.RestartPolicy,
+		MaxRetries:    formatIntInput(int64(s.MaxRetries)),
+	}
+}
+
+// Parse validates the input and converts it into ResourceSettings. All
+// problems are reported together so the form can show them at once.
+func (in ResourceInput) Parse() (ResourceSettings, error) {
+	var s ResourceSettings
+	var errs []error
+
+	var err error
+	if s.Memory, err = parseMemoryInput(in.Memory); err != nil {
+		errs = append(errs, fmt.Errorf("memory: %w", err))
+	} else if s.Memory != 0 && s.Memory < minMemoryBytes {
+		errs = append(errs, fmt.Errorf("memory: must be at least %s", units.BytesSize(minMemoryBytes)))
+	}
+
+	if s.MemorySwap, err = parseMemoryInput(in.MemorySwap); err != nil {
+		errs = append(errs, fmt.Errorf("memory-swap: %w", err))
+	} else if s.MemorySwap > 0 {
+		if s.Memory == 0 {
+			errs = append(errs, errors.New("memory-swap: requires a memory limit"))
+		} else if s.MemorySwap < s.Memory {
+			errs = append(errs, errors.New("memory-swap: must be greater than or equal to memory"))
+		}
+	}
+
+	if s.CPUShares, err = parseIntInput(in.CPUShares); err != nil {
+		errs = append(errs, fmt.Errorf("cpu-shares: %w", err))
+	} else if s.CPUShares < 0 || (s.CPUShares > 0 && s.CPUShares < 2) {
+		errs = append(errs, errors.New("cpu-shares: must be 0 or at least 2"))
+	}
+
+	if s.CPUQuota, err = parseIntInput(in.CPUQuota); err != nil {
+		errs = append(errs, fmt.Errorf("cpu-quota: %w", err))
+	} else if s.CPUQuota != 0 && s.CPUQuota != -1 && s.CPUQuota < minCPUQuota {
+		errs = append(errs, fmt.Errorf("cpu-quota: must be -1, 0 or at least %d", minCPUQuota))
+	}
+
+	if s.CPUPeriod, err = parseIntInput(in.CPUPeriod); err != nil {
+		errs = append(errs, fmt.Errorf("cpu-period: %w", err))
+	} else if s.CPUPeriod != 0 && (s.CPUPeriod < minCPUPeriod || s.CPUPeriod > maxCPUPeriod) {
+		errs = append(errs, fmt.Errorf("cpu-period: must be between %d and %d", minCPUPeriod, maxCPUPeriod))
+	}
+
+	s.CpusetCpus = strings.ReplaceAll(strings.TrimSpace(in.CpusetCpus), " ", "")
+	if err := validateCpuset(s.CpusetCpus); err != nil {
+		errs = append(errs, fmt.Errorf("cpuset-cpus: %w", err))
+	}
+
+	if s.PidsLimit, err = parseIntInput(in.PidsLimit); err != nil {
+		errs = append(errs, fmt.Errorf("pids-limit: %w", err))
+	} else if s.PidsLimit < -1 {
+		errs = append(errs, errors.New("pids-limit: must be -1, 0 or positive"))
+	}
+
+	s.RestartPolicy = strings.TrimSpace(in.RestartPolicy)
+	if s.RestartPolicy == "" {
+		s.RestartPolicy = string(container.RestartPolicyDisabled)
+	}
+	retries, err := parseIntInput(in.MaxRetries)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("max-retries: %w", err))
+	}
+	s.MaxRetries = int(retries)
+	policy := container.RestartPolicy{Name: container.RestartPolicyMode(s.RestartPolicy), MaximumRetryCount: s.MaxRetries}
+	if err := container.ValidateRestartPolicy(policy); err != nil {
+		errs = append(errs, fmt.Errorf("restart: %w", err))
+	}
+
+	return s, errors.Join(errs...)
+}
+
+// Changes describes every field that differs between two settings, formatted
+// as "field: old → new".
+func (s ResourceSettings) Changes(updated ResourceSettings) []string {
+	var changes []string
+	add := func(field, before, after string) {
+		if before != after {
+			changes = append(changes, fmt.Sprintf("%s: %s → %s", field, before, after))
+		}
+	}
+
+	add("memory", formatMemoryLimit(s.Memory), formatMemoryLimit(updated.Memory))
+	add("memory-swap", formatSwapLimit(s.MemorySwap), formatSwapLimit(updated.MemorySwap))
+	add("cpu-shares", formatIntLimit(s.CPUShares), formatIntLimit(updated.CPUShares))
+	add("cpu-quota", formatIntLimit(s.CPUQuota), formatIntLimit(updated.CPUQuota))
+	add("cpu-period", formatIntLimit(s.CPUPeriod), formatIntLimit(updated.CPUPeriod))
+	add("cpuset-cpus", formatStringLimit(s.CpusetCpus), formatStringLimit(updated.CpusetCpus))
+	add("pids-limit", formatIntLimit(s.PidsLimit), formatIntLimit(updated.PidsLimit))
+	add("restart", formatRestartPolicy(s.RestartPolicy, s.MaxRetries), formatRestartPolicy(updated.RestartPolicy, updated.MaxRetries))
+	return changes
+}
+
+// GetContainerResources reads the current updatable settings from inspect.
+func (c *Client) GetContainerResources(id string) (ResourceSettings, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	data, err := c.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return ResourceSettings{}, err
+	}
+	if data.ContainerJSONBase == nil || data.HostConfig == nil {
+		return ResourceSettings{}, fmt.Errorf("container %s has no host config", id)
+	}
+	return resourceSettingsFromHostConfig(data.HostConfig), nil
+}
+
+// UpdateContainerResources changes the container's settings from current to
+// updated with ContainerUpdate. It returns the settings the container has
+// afterwards, read back from inspect, and any warnings reported by the
+// daemon.
+func (c *Client) UpdateContainerResources(id string, current, updated ResourceSettings) (ResourceSettings, []string, error) {
+	cfg, err := current.updateConfig(updated)
+	if err != nil {
+		return ResourceSettings{}, nil, err
+	}
+
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	resp, err := c.cli.ContainerUpdate(ctx, id, cfg)
+	cancel()
+	if err != nil {
+		return ResourceSettings{}, nil, err
+	}
+
+	applied, err := c.GetContainerResources(id)
+	if err != nil {
+		return ResourceSettings{}, resp.Warnings, fmt.Errorf("read back limits: %w", err)
+	}
+	return applied, resp.Warnings, nil
+}
+
+func resourceSettingsFromHostConfig(hc *container.HostConfig) ResourceSettings {
+	s := ResourceSettings{
+		Memory:        hc.Memory,
+		MemorySwap:    hc.MemorySwap,
+		CPUShares:     hc.CPUShares,
+		CPUQuota:      hc.CPUQuota,
+		CPUPeriod:     hc.CPUPeriod,
+		CpusetCpus:    hc.CpusetCpus,
+		RestartPolicy: string(hc.RestartPolicy.Name),
+		MaxRetries:    hc.RestartPolicy.MaximumRetryCount,
+	}
+	if hc.PidsLimit != nil {
+		s.PidsLimit = *hc.PidsLimit
+	}
+	if s.RestartPolicy == "" {
+		s.RestartPolicy = string(container.RestartPolicyDisabled)
+	}
+	return s
+}
+
+// updateConfig builds the ContainerUpdate request that changes s to updated,
+// sending only the fields that differ. The daemon reads zero values as
+// "leave unchanged", so removed limits are sent as -1 where the daemon
+// accepts that, and rejected where it does not.
+func (s ResourceSettings) updateConfig(updated ResourceSettings) (container.UpdateConfig, error) {
+	var errs []error
+	keep := func(field string, removed bool) {
+		if removed {
+			errs = append(errs, fmt.Errorf("%s: cannot be removed in place; recreate the container instead", field))
+		}
+	}
+	keep("memory", s.Memory > 0 && updated.Memory == 0)
+	keep("cpu-shares", s.CPUShares > 0 && updated.CPUShares == 0)
+	keep("cpu-period", s.CPUPeriod > 0 && updated.CPUPeriod == 0)
+	keep("cpuset-cpus", s.CpusetCpus != "" && updated.CpusetCpus == "")
+	if len(errs) > 0 {
+		return container.UpdateConfig{}, errors.Join(errs...)
+	}
+
+	// Only changed fields are sent, so a limit the form cannot show exactly
+	// is never rewritten by an edit to another field.
+	var cfg container.UpdateConfig
+	if updated.Memory != s.Memory {
+		cfg.Memory = updated.Memory
+	}
+	if updated.MemorySwap != s.MemorySwap {
+		cfg.MemorySwap = updated.MemorySwap
+		if cfg.MemorySwap == 0 {
+			cfg.MemorySwap = -1
+		}
+	}
+	if updated.CPUShares != s.CPUShares {
+		cfg.CPUShares = updated.CPUShares
+	}
+	if updated.CPUQuota != s.CPUQuota {
+		cfg.CPUQuota = updated.CPUQuota
+		if cfg.CPUQuota == 0 {
+			cfg.CPUQuota = -1
+		}
+	}
+	if updated.CPUPeriod != s.CPUPeriod {
+		cfg.CPUPeriod = updated.CPUPeriod
+	}
+	if updated.CpusetCpus != s.CpusetCpus {
+		cfg.CpusetCpus = updated.CpusetCpus
+	}
+	if updated.PidsLimit != s.PidsLimit {
+		pids := updated.PidsLimit
+		if pids == 0 {
+			// nil means "leave unchanged"; -1 clears an existing limit.
+			pids = -1
+		}
+		cfg.PidsLimit = &pids
+	}
+	if updated.RestartPolicy != s.RestartPolicy || updated.MaxRetries != s.MaxRetries {
+		cfg.RestartPolicy = container.RestartPolicy{
+			Name:              container.RestartPolicyMode(updated.RestartPolicy),
+			MaximumRetryCount: updated.MaxRetries,
+		}
+	}
+	return cfg, nil
+}
+
+func validateCpuset(value string) error {
+	if value == "" {
+		return nil
+	}
+	if !cpusetPattern.MatchString(value) {
+		return fmt.Errorf("invalid format %q (expected e.g. 0-3 or 0,2)", value)
+	}
+	for _, part := range strings.Split(value, ",") {
+		bounds := strings.SplitN(part, "-", 2)
+		if len(bounds) != 2 {
+			continue
+		}
+		lo, _ := strconv.Atoi(bounds[0])
+		hi, _ := strconv.Atoi(bounds[1])
+		if lo > hi {
+			return fmt.Errorf("invalid range %q", part)
+		}
+	}
+	return nil
+}
+
+func parseMemoryInput(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	switch value {
+	case "", "0":
+		return 0, nil
+	case "-1":
+		return -1, nil
+	}
+	return units.RAMInBytes(value)
+}
+
+func parseIntInput(value string) (int64, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number %q", value)
+	}
+	return n, nil
+}
+
+func formatMemoryInput(value int64) string {
+	switch {
+	case value == 0:
+		return ""
+	case value < 0:
+		return strconv.FormatInt(value, 10)
+	}
+	return exactBytes(value)
+}
+
+// exactBytes renders a byte count in the largest binary unit that divides
+// it, or in bytes, so parseMemoryInput reads back the same value.
+func exactBytes(value int64) string {
+	for _, unit := range []struct {
+		size   int64
+		suffix string
+	}{{units.TiB, "TiB"}, {units.GiB, "GiB"}, {units.MiB, "MiB"}} {
+		if value%unit.size == 0 {
+			return fmt.Sprintf("%d%s", value/unit.size, unit.suffix)
+		}
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func formatIntInput(value int64) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func formatMemoryLimit(value int64) string {
+	if value <= 0 {
+		return "unlimited"
+	}
+	return exactBytes(value)
+}
+
+func formatSwapLimit(value int64) string {
+	switch {
+	case value == 0:
+		return "default"
+	case value < 0:
+		return "unlimited"
+	}
+	return exactBytes(value)
+}
+
+func formatIntLimit(value int64) string {
+	if value == 0 || value == -1 {
+		return "unlimited"
+	}
+	return strconv.FormatInt(value, 10)
+}
+
+func formatStringLimit(value string) string {
+	if value == "" {
+		return "all"
+	}
+	return value
+}
+
+func formatRestartPolicy(name string, retries int) string {
+	if name == string(container.RestartPolicyOnFailure) && retries > 0 {
+		return fmt.Sprintf("%s:%d", name, retries)
+	}
+	return name
+}
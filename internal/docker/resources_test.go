@@ -0,0 +1,213 @@
+package docker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestResourceInputParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   ResourceInput
+		want    ResourceSettings
+		wantErr bool
+	}{
+		{
+			name:  "empty",
+			input: ResourceInput{},
+			want:  ResourceSettings{RestartPolicy: "no"},
+		},
+		{
+			name: "full",
+			input: ResourceInput{
+				Memory:        "512m",
+				MemorySwap:    "1g",
+				CPUShares:     "512",
+				CPUQuota:      "50000",
+				CPUPeriod:     "100000",
+				CpusetCpus:    "0-1, 3",
+				PidsLimit:     "100",
+				RestartPolicy: "on-failure",
+				MaxRetries:    "5",
+			},
+			want: ResourceSettings{
+				Memory:        512 * 1024 * 1024,
+				MemorySwap:    1024 * 1024 * 1024,
+				CPUShares:     512,
+				CPUQuota:      50000,
+				CPUPeriod:     100000,
+				CpusetCpus:    "0-1,3",
+				PidsLimit:     100,
+				RestartPolicy: "on-failure",
+				MaxRetries:    5,
+			},
+		},
+		{
+			name:  "unlimitedSwap",
+			input: ResourceInput{Memory: "256m", MemorySwap: "-1"},
+			want:  ResourceSettings{Memory: 256 * 1024 * 1024, MemorySwap: -1, RestartPolicy: "no"},
+		},
+		{"memoryTooSmall", ResourceInput{Memory: "1m"}, ResourceSettings{}, true},
+		{"memoryGarbage", ResourceInput{Memory: "lots"}, ResourceSettings{}, true},
+		{"swapBelowMemory", ResourceInput{Memory: "1g", MemorySwap: "512m"}, ResourceSettings{}, true},
+		{"swapWithoutMemory", ResourceInput{MemorySwap: "1g"}, ResourceSettings{}, true},
+		{"sharesTooSmall", ResourceInput{CPUShares: "1"}, ResourceSettings{}, true},
+		{"quotaTooSmall", ResourceInput{CPUQuota: "10"}, ResourceSettings{}, true},
+		{"periodOutOfRange", ResourceInput{CPUPeriod: "5000000"}, ResourceSettings{}, true},
+		{"cpusetInvalid", ResourceInput{CpusetCpus: "0-"}, ResourceSettings{}, true},
+		{"cpusetReversed", ResourceInput{CpusetCpus: "3-1"}, ResourceSettings{}, true},
+		{"pidsNegative", ResourceInput{PidsLimit: "-5"}, ResourceSettings{}, true},
+		{"retriesWithoutOnFailure", ResourceInput{RestartPolicy: "always", MaxRetries: "3"}, ResourceSettings{}, true},
+		{"unknownPolicy", ResourceInput{RestartPolicy: "sometimes"}, ResourceSettings{}, true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := tt.input.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceSettingsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	original := ResourceSettings{
+		Memory:        512 * 1024 * 1024,
+		MemorySwap:    -1,
+		CPUShares:     1024,
+		CpusetCpus:    "0,2",
+		PidsLimit:     200,
+		RestartPolicy: "unless-stopped",
+	}
+	got, err := original.Input().Parse()
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, original) {
+		t.Fatalf("round trip = %+v, want %+v", got, original)
+	}
+}
+
+func TestResourceSettingsInexactRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	// 2000000000 bytes is not a whole number of any binary unit.
+	original := ResourceSettings{Memory: 2000000000, MemorySwap: 3 * 1024 * 1024 * 1024, RestartPolicy: "no"}
+	in := original.Input()
+	if in.Memory != "2000000000" || in.MemorySwap != "3GiB" {
+		t.Fatalf("Input() memory = %q swap = %q, want 2000000000 and 3GiB", in.Memory, in.MemorySwap)
+	}
+	got, err := in.Parse()
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(got, original) {
+		t.Fatalf("round trip = %+v, want %+v", got, original)
+	}
+	if changes := original.Changes(ResourceSettings{Memory: 2000000001, MemorySwap: original.MemorySwap, RestartPolicy: "no"}); len(changes) != 1 {
+		t.Fatalf("Changes() = %q, want the one-byte memory change", changes)
+	}
+}
+
+func TestResourceSettingsChanges(t *testing.T) {
+	t.Parallel()
+
+	before := ResourceSettings{Memory: 256 * 1024 * 1024, RestartPolicy: "no"}
+	after := ResourceSettings{Memory: 512 * 1024 * 1024, PidsLimit: 100, RestartPolicy: "on-failure", MaxRetries: 3}
+
+	want := []string{
+		"memory: 256MiB → 512MiB",
+		"pids-limit: unlimited → 100",
+		"restart: no → on-failure:3",
+	}
+	if got := before.Changes(after); !reflect.DeepEqual(got, want) {
+		t.Fatalf("Changes() = %q, want %q", got, want)
+	}
+	if got := before.Changes(before); len(got) != 0 {
+		t.Fatalf("Changes() on identical settings = %q, want none", got)
+	}
+}
+
+func TestResourceSettingsUpdateConfig(t *testing.T) {
+	t.Parallel()
+
+	limited := ResourceSettings{
+		Memory:     256 * 1024 * 1024,
+		MemorySwap: 512 * 1024 * 1024,
+		CPUShares:  512,
+		CPUQuota:   50000,
+		CPUPeriod:  100000,
+		CpusetCpus: "0-1",
+		PidsLimit:  100,
+	}
+
+	t.Run("removeSwapAndQuota", func(t *testing.T) {
+		t.Parallel()
+		updated := limited
+		updated.MemorySwap, updated.CPUQuota, updated.PidsLimit = 0, 0, 0
+		cfg, err := limited.updateConfig(updated)
+		if err != nil {
+			t.Fatalf("updateConfig() error = %v", err)
+		}
+		if cfg.MemorySwap != -1 || cfg.CPUQuota != -1 || cfg.PidsLimit == nil || *cfg.PidsLimit != -1 {
+			t.Fatalf("removed limits sent as swap=%d quota=%d pids=%v, want -1", cfg.MemorySwap, cfg.CPUQuota, cfg.PidsLimit)
+		}
+		if cfg.Memory != 0 || cfg.CPUShares != 0 || cfg.CpusetCpus != "" {
+			t.Fatalf("kept limits sent: %+v", cfg.Resources)
+		}
+	})
+
+	t.Run("onlyChangedFields", func(t *testing.T) {
+		t.Parallel()
+		current := limited
+		current.Memory, current.MemorySwap = 2000000000, -1
+		current.RestartPolicy = "no"
+		updated := current
+		updated.CPUShares = 1024
+		cfg, err := current.updateConfig(updated)
+		if err != nil {
+			t.Fatalf("updateConfig() error = %v", err)
+		}
+		want := container.UpdateConfig{Resources: container.Resources{CPUShares: 1024}}
+		if !reflect.DeepEqual(cfg, want) {
+			t.Fatalf("updateConfig() = %+v, want only cpu-shares", cfg)
+		}
+	})
+
+	t.Run("unsetStaysUnset", func(t *testing.T) {
+		t.Parallel()
+		cfg, err := ResourceSettings{}.updateConfig(ResourceSettings{})
+		if err != nil {
+			t.Fatalf("updateConfig() error = %v", err)
+		}
+		if cfg.MemorySwap != 0 || cfg.CPUQuota != 0 {
+			t.Fatalf("unset limits sent as swap=%d quota=%d, want 0", cfg.MemorySwap, cfg.CPUQuota)
+		}
+	})
+
+	t.Run("removeUnsupported", func(t *testing.T) {
+		t.Parallel()
+		_, err := limited.updateConfig(ResourceSettings{MemorySwap: limited.MemorySwap})
+		if err == nil {
+			t.Fatalf("expected error removing memory, cpu-shares, cpu-period and cpuset-cpus")
+		}
+		for _, field := range []string{"memory:", "cpu-shares:", "cpu-period:", "cpuset-cpus:"} {
+			if !strings.Contains(err.Error(), field) {
+				t.Fatalf("error %q does not mention %s", err, field)
+			}
+		}
+	})
+}
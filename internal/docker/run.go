@@ -0,0 +1,399 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+)
+
+// RunSpec describes a container to create from an image, mirroring the most
+// common `docker run` flags.
+type RunSpec struct {
+	Image     string            `json:"image"`
+	Name      string            `json:"name,omitempty"`
+	Command   []string          `json:"command,omitempty"`
+	Env       []string          `json:"env,omitempty"`
+	Ports     []string          `json:"ports,omitempty"`
+	Mounts    []string          `json:"mounts,omitempty"`
+	Networks  []string          `json:"networks,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Resources ResourceSettings  `json:"resources"`
+}
+
+// RunInput is the textual form of RunSpec as edited in the UI. List fields are
+// comma separated; a literal comma can be written as `\,`.
+type RunInput struct {
+	Image     string
+	Name      string
+	Command   string
+	Env       string
+	Ports     string
+	Mounts    string
+	Networks  string
+	Labels    string
+	Resources ResourceInput
+}
+
+// Input renders the spec as editable text.
+func (s RunSpec) Input() RunInput {
+	labels := make([]string, 0, len(s.Labels))
+	for _, key := range sortedKeys(s.Labels) {
+		labels = append(labels, key+"="+s.Labels[key])
+	}
+	quoted := make([]string, len(s.Command))
+	for i, arg := range s.Command {
+		quoted[i] = shellQuote(arg)
+	}
+	return RunInput{
+		Image:     s.Image,
+		Name:      s.Name,
+		Command:   strings.Join(quoted, " "),
+		Env:       joinList(s.Env),
+		Ports:     joinList(s.Ports),
+		Mounts:    joinList(s.Mounts),
+		Networks:  joinList(s.Networks),
+		Labels:    joinList(labels),
+		Resources: s.Resources.Input(),
+	}
+}
+
+// Parse validates the input and converts it into a RunSpec.
+func (in RunInput) Parse() (RunSpec, error) {
+	var errs []error
+	spec := RunSpec{
+		Image:    strings.TrimSpace(in.Image),
+		Name:     strings.TrimSpace(in.Name),
+		Env:      splitList(in.Env),
+		Ports:    splitList(in.Ports),
+		Mounts:   splitList(in.Mounts),
+		Networks: splitList(in.Networks),
+	}
+
+	if spec.Image == "" {
+		errs = append(errs, errors.New("image: required"))
+	}
+
+	command, err := splitCommand(in.Command)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("command: %w", err))
+	}
+	spec.Command = command
+
+	for _, kv := range spec.Env {
+		if strings.HasPrefix(kv, "=") {
+			errs = append(errs, fmt.Errorf("env: invalid entry %q", kv))
+		}
+	}
+
+	if _, _, err := nat.ParsePortSpecs(spec.Ports); err != nil {
+		errs = append(errs, fmt.Errorf("ports: %w", err))
+	}
+
+	for _, m := range spec.Mounts {
+		if err := validateMount(m); err != nil {
+			errs = append(errs, fmt.Errorf("mounts: %w", err))
+		}
+	}
+
+	labels, err := parseKeyValues(in.Labels)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("labels: %w", err))
+	}
+	spec.Labels = labels
+
+	resources, err := in.Resources.Parse()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	spec.Resources = resources
+
+	return spec, errors.Join(errs...)
+}
+
+// DockerRunCommand renders the equivalent `docker run` invocation.
+func (s RunSpec) DockerRunCommand() string {
+	args := []string{"docker", "run", "-d"}
+	add := func(flag, value string) {
+		args = append(args, flag, shellQuote(value))
+	}
+
+	if s.Name != "" {
+		add("--name", s.Name)
+	}
+	r := s.Resources
+	if r.RestartPolicy != "" && r.RestartPolicy != string(container.RestartPolicyDisabled) {
+		add("--restart", formatRestartPolicy(r.RestartPolicy, r.MaxRetries))
+	}
+	if r.Memory > 0 {
+		add("--memory", formatMemoryFlag(r.Memory))
+	}
+	if r.MemorySwap != 0 {
+		add("--memory-swap", formatMemoryFlag(r.MemorySwap))
+	}
+	if r.CPUShares > 0 {
+		add("--cpu-shares", fmt.Sprint(r.CPUShares))
+	}
+	if r.CPUQuota != 0 {
+		add("--cpu-quota", fmt.Sprint(r.CPUQuota))
+	}
+	if r.CPUPeriod > 0 {
+		add("--cpu-period", fmt.Sprint(r.CPUPeriod))
+	}
+	if r.CpusetCpus != "" {
+		add("--cpuset-cpus", r.CpusetCpus)
+	}
+	if r.PidsLimit != 0 {
+		add("--pids-limit", fmt.Sprint(r.PidsLimit))
+	}
+	for _, kv := range s.Env {
+		add("-e", kv)
+	}
+	for _, p := range s.Ports {
+		add("-p", p)
+	}
+	for _, m := range s.Mounts {
+		add("-v", m)
+	}
+	for _, n := range s.Networks {
+		add("--network", n)
+	}
+	for _, key := range sortedKeys(s.Labels) {
+		add("--label", key+"="+s.Labels[key])
+	}
+
+	args = append(args, shellQuote(s.Image))
+	for _, arg := range s.Command {
+		args = append(args, shellQuote(arg))
+	}
+	return strings.Join(args, " ")
+}
+
+// RunContainer creates and starts a container from spec and returns its ID.
+// A container that fails to start is removed again.
+func (c *Client) RunContainer(spec RunSpec) (string, error) {
+	cs, err := spec.createSpec()
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := timeoutCtx(lifecycleTimeout)
+	defer cancel()
+
+	created, err := c.cli.ContainerCreate(ctx, cs.Config, cs.HostConfig, cs.Networking, nil, spec.Name)
+	if err != nil {
+		return "", fmt.Errorf("create: %w", err)
+	}
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		rmErr := c.cli.ContainerRemove(ctx, created.ID, container.RemoveOptions{Force: true})
+		return "", errors.Join(fmt.Errorf("start: %w", err), rmErr)
+	}
+	return created.ID, nil
+}
+
+func (s RunSpec) createSpec() (createSpec, error) {
+	exposed, bindings, err := nat.ParsePortSpecs(s.Ports)
+	if err != nil {
+		return createSpec{}, err
+	}
+
+	cfg := &container.Config{
+		Image:        s.Image,
+		Cmd:          s.Command,
+		Env:          s.Env,
+		Labels:       s.Labels,
+		ExposedPorts: exposed,
+	}
+
+	// Nothing is removed starting from no limits, so this cannot fail.
+	update, _ := ResourceSettings{}.updateConfig(s.Resources)
+	hostCfg := &container.HostConfig{
+		Binds:         s.Mounts,
+		PortBindings:  bindings,
+		RestartPolicy: update.RestartPolicy,
+		Resources:     update.Resources,
+	}
+	if s.Resources.PidsLimit == 0 {
+		hostCfg.PidsLimit = nil
+	}
+
+	spec := createSpec{Config: cfg, HostConfig: hostCfg}
+	if len(s.Networks) > 0 {
+		hostCfg.NetworkMode = container.NetworkMode(s.Networks[0])
+		endpoints := make(map[string]*network.EndpointSettings, len(s.Networks))
+		for _, n := range s.Networks {
+			endpoints[n] = &network.EndpointSettings{}
+		}
+		spec.Networking = &network.NetworkingConfig{EndpointsConfig: endpoints}
+	}
+	return spec, nil
+}
+
+// validateMount checks a `-v` style mount: source:target[:options].
+func validateMount(value string) error {
+	parts := strings.Split(value, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return fmt.Errorf("invalid mount %q (expected source:target[:ro])", value)
+	}
+	if parts[0] == "" {
+		return fmt.Errorf("invalid mount %q: empty source", value)
+	}
+	if !strings.HasPrefix(parts[1], "/") {
+		return fmt.Errorf("invalid mount %q: target must be an absolute path", value)
+	}
+	if len(parts) == 3 {
+		for _, opt := range strings.Split(parts[2], ",") {
+			switch opt {
+			case "ro", "rw", "z", "Z", "shared", "slave", "private", "rshared", "rslave", "rprivate", "nocopy":
+			default:
+				return fmt.Errorf("invalid mount %q: unknown option %q", value, opt)
+			}
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma separated list, honouring `\,` escapes and
+// dropping empty entries.
+func splitList(value string) []string {
+	var out []string
+	var cur strings.Builder
+	flush := func() {
+		if item := strings.TrimSpace(cur.String()); item != "" {
+			out = append(out, item)
+		}
+		cur.Reset()
+	}
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && i+1 < len(value) && value[i+1] == ',':
+			cur.WriteByte(',')
+			i++
+		case value[i] == ',':
+			flush()
+		default:
+			cur.WriteByte(value[i])
+		}
+	}
+	flush()
+	return out
+}
+
+// parseKeyValues parses a comma separated list of key=value pairs. It returns
+// nil when the list is empty.
+func parseKeyValues(value string) (map[string]string, error) {
+	var out map[string]string
+	for _, kv := range splitList(value) {
+		key, val, _ := strings.Cut(kv, "=")
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return out, fmt.Errorf("invalid entry %q", kv)
+		}
+		if out == nil {
+			out = make(map[string]string)
+		}
+		out[key] = strings.TrimSpace(val)
+	}
+	return out, nil
+}
+
+func joinList(items []string) string {
+	escaped := make([]string, len(items))
+	for i, item := range items {
+		escaped[i] = strings.ReplaceAll(item, ",", `\,`)
+	}
+	return strings.Join(escaped, ", ")
+}
+
+// splitCommand splits a command line into arguments using POSIX-like single
+// and double quoting rules.
+func splitCommand(value string) ([]string, error) {
+	var args []string
+	var cur strings.Builder
+	inArg := false
+	var quote byte
+
+	for i := 0; i < len(value); i++ {
+		ch := value[i]
+		switch {
+		case quote != 0:
+			if ch == quote {
+				quote = 0
+			} else if ch == '\\' && quote == '"' && i+1 < len(value) {
+				i++
+				cur.WriteByte(value[i])
+			} else {
+				cur.WriteByte(ch)
+			}
+		case ch == '\'' || ch == '"':
+			quote = ch
+			inArg = true
+		case ch == '\\' && i+1 < len(value):
+			i++
+			cur.WriteByte(value[i])
+			inArg = true
+		case ch == ' ' || ch == '\t':
+			if inArg {
+				args = append(args, cur.String())
+				cur.Reset()
+				inArg = false
+			}
+		default:
+			cur.WriteByte(ch)
+			inArg = true
+		}
+	}
+	if quote != 0 {
+		return nil, errors.New("unterminated quote")
+	}
+	if inArg {
+		args = append(args, cur.String())
+	}
+	return args, nil
+}
+
+// shellQuote quotes value for a POSIX shell when it contains special
+// characters.
+func shellQuote(value string) string {
+	if value == "" {
+		return "''"
+	}
+	if strings.IndexFunc(value, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("-_./:=@,+%", r))
+	}) < 0 {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+func formatMemoryFlag(value int64) string {
+	const (
+		kib = 1024
+		mib = 1024 * kib
+		gib = 1024 * mib
+	)
+	switch {
+	case value < 0:
+		return fmt.Sprint(value)
+	case value%gib == 0:
+		return fmt.Sprintf("%dg", value/gib)
+	case value%mib == 0:
+		return fmt.Sprintf("%dm", value/mib)
+	case value%kib == 0:
+		return fmt.Sprintf("%dk", value/kib)
+	}
+	return fmt.Sprint(value)
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,136 @@
+package docker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunInputParse(t *testing.T) {
+	t.Parallel()
+
+	in := RunInput{
+		Image:    "nginx:1.27",
+		Name:     "web",
+		Command:  `nginx -g "daemon off;"`,
+		Env:      `MODE=prod, LIST=a\,b`,
+		Ports:    "8080:80, 53:53/udp",
+		Mounts:   "data:/var/lib/data, /srv/conf:/etc/nginx:ro",
+		Networks: "frontend, backend",
+		Labels:   "team=web, tier=edge",
+		Resources: ResourceInput{
+			Memory:        "256m",
+			RestartPolicy: "on-failure",
+			MaxRetries:    "3",
+		},
+	}
+
+	got, err := in.Parse()
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	want := RunSpec{
+		Image:    "nginx:1.27",
+		Name:     "web",
+		Command:  []string{"nginx", "-g", "daemon off;"},
+		Env:      []string{"MODE=prod", "LIST=a,b"},
+		Ports:    []string{"8080:80", "53:53/udp"},
+		Mounts:   []string{"data:/var/lib/data", "/srv/conf:/etc/nginx:ro"},
+		Networks: []string{"frontend", "backend"},
+		Labels:   map[string]string{"team": "web", "tier": "edge"},
+		Resources: ResourceSettings{
+			Memory:        256 * 1024 * 1024,
+			RestartPolicy: "on-failure",
+			MaxRetries:    3,
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Parse() = %+v, want %+v", got, want)
+	}
+
+	roundTrip, err := got.Input().Parse()
+	if err != nil {
+		t.Fatalf("round trip Parse() unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(roundTrip, want) {
+		t.Fatalf("round trip = %+v, want %+v", roundTrip, want)
+	}
+}
+
+func TestRunInputParseErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input RunInput
+	}{
+		{"missingImage", RunInput{}},
+		{"badPort", RunInput{Image: "x", Ports: "http:80"}},
+		{"relativeTarget", RunInput{Image: "x", Mounts: "data:var/lib"}},
+		{"badMountOption", RunInput{Image: "x", Mounts: "data:/data:rx"}},
+		{"badLabel", RunInput{Image: "x", Labels: "=value"}},
+		{"unterminatedQuote", RunInput{Image: "x", Command: `sh -c "echo`}},
+		{"badMemory", RunInput{Image: "x", Resources: ResourceInput{Memory: "huge"}}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := tt.input.Parse(); err == nil {
+				t.Fatalf("Parse() expected error")
+			}
+		})
+	}
+}
+
+func TestDockerRunCommand(t *testing.T) {
+	t.Parallel()
+
+	spec := RunSpec{
+		Image:    "nginx:1.27",
+		Name:     "web",
+		Command:  []string{"nginx", "-g", "daemon off;"},
+		Env:      []string{"MODE=prod"},
+		Ports:    []string{"8080:80"},
+		Mounts:   []string{"data:/data"},
+		Networks: []string{"frontend"},
+		Labels:   map[string]string{"tier": "edge", "team": "web"},
+		Resources: ResourceSettings{
+			Memory:        512 * 1024 * 1024,
+			PidsLimit:     100,
+			RestartPolicy: "unless-stopped",
+		},
+	}
+	want := "docker run -d --name web --restart unless-stopped --memory 512m --pids-limit 100 " +
+		"-e MODE=prod -p 8080:80 -v data:/data --network frontend --label team=web --label tier=edge " +
+		"nginx:1.27 nginx -g 'daemon off;'"
+	if got := spec.DockerRunCommand(); got != want {
+		t.Fatalf("DockerRunCommand() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRunSpecCreateSpec(t *testing.T) {
+	t.Parallel()
+
+	spec := RunSpec{
+		Image:    "redis:7",
+		Ports:    []string{"6379:6379"},
+		Networks: []string{"backend", "cache"},
+	}
+	cs, err := spec.createSpec()
+	if err != nil {
+		t.Fatalf("createSpec() unexpected error: %v", err)
+	}
+	if cs.HostConfig.NetworkMode != "backend" {
+		t.Fatalf("network mode = %q, want backend", cs.HostConfig.NetworkMode)
+	}
+	if len(cs.Networking.EndpointsConfig) != 2 {
+		t.Fatalf("endpoints = %v, want 2", cs.Networking.EndpointsConfig)
+	}
+	if cs.HostConfig.PidsLimit != nil {
+		t.Fatalf("unset pids limit should not be sent")
+	}
+	if _, ok := cs.HostConfig.PortBindings["6379/tcp"]; !ok {
+		t.Fatalf("expected port binding for 6379/tcp, got %v", cs.HostConfig.PortBindings)
+	}
+}
@@ -0,0 +1,202 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+)
+
+const pullTimeout = 5 * time.Minute
+
+// Update statuses reported per container by UpdateContainers.
+const (
+	UpdateStatusUpdated  = "updated"
+	UpdateStatusUpToDate = "up-to-date"
+	UpdateStatusSkipped  = "skipped"
+	UpdateStatusFailed   = "failed"
+)
+
+// UpdateResult reports the outcome of updating a single container.
+type UpdateResult struct {
+	Container  string
+	Image      string
+	OldImageID string
+	NewImageID string
+	Status     string
+	Err        error
+}
+
+// UpdateProgress is called after each step of UpdateContainers.
+type UpdateProgress func(done, total int, message string)
+
+// imageUpdater is the subset of Client used by updateContainers, split out
+// so the workflow can be exercised against a fake registry in tests.
+type imageUpdater interface {
+	PullImage(ref string) error
+	ResolveImageID(ref string) (string, error)
+	RecreateContainer(id string, opts RecreateOptions) (RecreateResult, error)
+}
+
+// PullImage pulls ref from its registry and waits for the pull to finish.
+func (c *Client) PullImage(ref string) error {
+	ctx, cancel := timeoutCtx(pullTimeout)
+	defer cancel()
+
+	stream, err := c.cli.ImagePull(ctx, ref, image.PullOptions{})
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	// The daemon reports pull failures inside the JSON stream.
+	return jsonmessage.DisplayJSONMessagesStream(stream, io.Discard, 0, false, nil)
+}
+
+// ResolveImageID returns the ID of the local image ref currently points to.
+func (c *Client) ResolveImageID(ref string) (string, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	img, err := c.cli.ImageInspect(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+	return img.ID, nil
+}
+
+// UpdateContainers pulls (optionally) the tag of every given container's
+// image once, then recreates each container whose image ID no longer matches
+// what its tag resolves to. Old containers are removed on success.
+func (c *Client) UpdateContainers(containers []ContainerInfo, pull bool, progress UpdateProgress) []UpdateResult {
+	return updateContainers(c, containers, pull, progress)
+}
+
+func updateContainers(u imageUpdater, containers []ContainerInfo, pull bool, progress UpdateProgress) []UpdateResult {
+	if progress == nil {
+		progress = func(int, int, string) {}
+	}
+
+	refs := make(map[string]string) // container image -> normalized tag
+	seen := make(map[string]struct{})
+	var order []string
+	for _, ctr := range containers {
+		ref := updatableRef(ctr.Image)
+		refs[ctr.Image] = ref
+		if _, ok := seen[ref]; ok || ref == "" {
+			continue
+		}
+		seen[ref] = struct{}{}
+		order = append(order, ref)
+	}
+
+	total := len(containers)
+	if pull {
+		total += len(order)
+	}
+	done := 0
+
+	pullErrs := make(map[string]error)
+	resolved := make(map[string]string)
+	for _, ref := range order {
+		if pull {
+			progress(done, total, fmt.Sprintf("Pulling %s", ref))
+			if err := u.PullImage(ref); err != nil {
+				pullErrs[ref] = fmt.Errorf("pull %s: %w", ref, err)
+			}
+			done++
+		}
+		if id, err := u.ResolveImageID(ref); err == nil {
+			resolved[ref] = id
+		} else if pullErrs[ref] == nil {
+			pullErrs[ref] = fmt.Errorf("resolve %s: %w", ref, err)
+		}
+	}
+
+	results := make([]UpdateResult, 0, len(containers))
+	for _, ctr := range containers {
+		ref := refs[ctr.Image]
+		result := UpdateResult{Container: ctr.Name, Image: ctr.Image, OldImageID: ctr.ImageID}
+
+		switch {
+		case ref == "":
+			result.Status = UpdateStatusSkipped
+			result.Err = fmt.Errorf("image %q is not a tag reference", ctr.Image)
+		case resolved[ref] == "":
+			result.Status = UpdateStatusFailed
+			result.Err = pullErrs[ref]
+		case resolved[ref] == ctr.ImageID:
+			result.Status = UpdateStatusUpToDate
+			result.NewImageID = ctr.ImageID
+			if pullErrs[ref] != nil {
+				result.Status = UpdateStatusFailed
+				result.Err = pullErrs[ref]
+			}
+		default:
+			progress(done, total, fmt.Sprintf("Recreating %s", ctr.Name))
+			result.NewImageID = resolved[ref]
+			if _, err := u.RecreateContainer(ctr.ID, RecreateOptions{RemoveOld: true}); err != nil {
+				result.Status = UpdateStatusFailed
+				result.Err = err
+			} else {
+				result.Status = UpdateStatusUpdated
+			}
+		}
+
+		results = append(results, result)
+		done++
+	}
+	progress(done, total, "Done")
+	return results
+}
+
+// localImageIDs maps every local repo tag (normalized) to its image ID.
+func (c *Client) localImageIDs(ctx context.Context) (map[string]string, error) {
+	images, err := c.cli.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	ids := make(map[string]string)
+	for _, img := range images {
+		for _, tag := range img.RepoTags {
+			if ref := updatableRef(tag); ref != "" {
+				ids[ref] = img.ID
+			}
+		}
+	}
+	return ids, nil
+}
+
+// isOutdated reports whether a container runs an older image than the one
+// its tag currently resolves to locally.
+func isOutdated(imageRef, imageID string, localIDs map[string]string) bool {
+	ref := updatableRef(imageRef)
+	if ref == "" || imageID == "" {
+		return false
+	}
+	current, ok := localIDs[ref]
+	return ok && current != imageID
+}
+
+// updatableRef normalizes a tag reference such as "nginx" to "nginx:latest".
+// It returns "" for image IDs and digest-pinned references, which cannot be
+// updated by pulling a tag.
+func updatableRef(ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "sha256:") || strings.Contains(ref, "@") {
+		return ""
+	}
+	named, err := reference.ParseDockerRef(ref)
+	if err != nil {
+		return ""
+	}
+	if _, ok := named.(reference.Tagged); !ok {
+		return ""
+	}
+	return reference.FamiliarString(named)
+}
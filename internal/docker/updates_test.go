@@ -0,0 +1,184 @@
+package docker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/image"
+)
+
+// fakeRegistry stands in for a registry plus the local image store: pulling
+// a tag makes the local tag resolve to the registry's image ID.
+type fakeRegistry struct {
+	remote     map[string]string
+	local      map[string]string
+	pulls      []string
+	recreated  []string
+	failCreate map[string]bool
+}
+
+func (f *fakeRegistry) PullImage(ref string) error {
+	f.pulls = append(f.pulls, ref)
+	id, ok := f.remote[ref]
+	if !ok {
+		return errors.New("manifest unknown")
+	}
+	f.local[ref] = id
+	return nil
+}
+
+func (f *fakeRegistry) ResolveImageID(ref string) (string, error) {
+	id, ok := f.local[ref]
+	if !ok {
+		return "", errors.New("no such image")
+	}
+	return id, nil
+}
+
+func (f *fakeRegistry) RecreateContainer(id string, opts RecreateOptions) (RecreateResult, error) {
+	if f.failCreate[id] {
+		return RecreateResult{RolledBack: true}, errors.New("start failed")
+	}
+	f.recreated = append(f.recreated, id)
+	return RecreateResult{OldID: id, NewID: id + "-new"}, nil
+}
+
+func TestUpdateContainers(t *testing.T) {
+	t.Parallel()
+
+	containers := []ContainerInfo{
+		{ID: "web1", Name: "web1", Image: "nginx", ImageID: "sha256:old"},
+		{ID: "web2", Name: "web2", Image: "nginx:latest", ImageID: "sha256:old"},
+		{ID: "cache", Name: "cache", Image: "redis:7", ImageID: "sha256:redis"},
+		{ID: "pinned", Name: "pinned", Image: "sha256:abcdef", ImageID: "sha256:abcdef"},
+		{ID: "gone", Name: "gone", Image: "private/app:1", ImageID: "sha256:app"},
+		{ID: "broken", Name: "broken", Image: "api:2", ImageID: "sha256:api-old"},
+	}
+
+	t.Run("pull", func(t *testing.T) {
+		t.Parallel()
+		reg := &fakeRegistry{
+			remote:     map[string]string{"nginx:latest": "sha256:new", "redis:7": "sha256:redis", "api:2": "sha256:api-new"},
+			local:      map[string]string{"nginx:latest": "sha256:old", "redis:7": "sha256:redis", "api:2": "sha256:api-old"},
+			failCreate: map[string]bool{"broken": true},
+		}
+
+		var lastDone, lastTotal int
+		results := updateContainers(reg, containers, true, func(done, total int, _ string) {
+			lastDone, lastTotal = done, total
+		})
+
+		want := map[string]string{
+			"web1":   UpdateStatusUpdated,
+			"web2":   UpdateStatusUpdated,
+			"cache":  UpdateStatusUpToDate,
+			"pinned": UpdateStatusSkipped,
+			"gone":   UpdateStatusFailed,
+			"broken": UpdateStatusFailed,
+		}
+		for _, r := range results {
+			if r.Status != want[r.Container] {
+				t.Errorf("%s: status = %s (err %v), want %s", r.Container, r.Status, r.Err, want[r.Container])
+			}
+		}
+		if len(reg.pulls) != 4 {
+			t.Errorf("pulls = %v, want each tag pulled once", reg.pulls)
+		}
+		if len(reg.recreated) != 2 {
+			t.Errorf("recreated = %v, want web1 and web2", reg.recreated)
+		}
+		if lastDone != lastTotal {
+			t.Errorf("progress ended at %d/%d", lastDone, lastTotal)
+		}
+	})
+
+	t.Run("localOnly", func(t *testing.T) {
+		t.Parallel()
+		reg := &fakeRegistry{
+			remote: map[string]string{"nginx:latest": "sha256:new"},
+			local:  map[string]string{"nginx:latest": "sha256:old", "redis:7": "sha256:redis"},
+		}
+		results := updateContainers(reg, containers[:3], false, nil)
+		if len(reg.pulls) != 0 {
+			t.Fatalf("local-only update should not pull, got %v", reg.pulls)
+		}
+		for _, r := range results {
+			if r.Status != UpdateStatusUpToDate {
+				t.Errorf("%s: status = %s, want up-to-date", r.Container, r.Status)
+			}
+		}
+	})
+}
+
+func TestIsOutdated(t *testing.T) {
+	t.Parallel()
+
+	local := map[string]string{"nginx:latest": "sha256:new", "example.com/app:1": "sha256:app"}
+	tests := []struct {
+		name    string
+		ref     string
+		imageID string
+		want    bool
+	}{
+		{"implicitLatest", "nginx", "sha256:old", true},
+		{"explicitTag", "nginx:latest", "sha256:new", false},
+		{"registryHost", "example.com/app:1", "sha256:old", true},
+		{"unknownTag", "redis:7", "sha256:old", false},
+		{"imageID", "sha256:old", "sha256:old", false},
+		{"digestPinned", "nginx@sha256:0123456789012345678901234567890123456789012345678901234567890123", "sha256:old", false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isOutdated(tt.ref, tt.imageID, local); got != tt.want {
+				t.Fatalf("isOutdated(%q) = %v, want %v", tt.ref, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQueryContainersAfterRetag(t *testing.T) {
+	t.Parallel()
+
+	// nginx:latest was pulled again after web was created, so the daemon
+	// lists web's image by ID; its inspect response still names the tag.
+	c := newFakeClient(t, fakeDaemon{
+		"GET /containers/json": []container.Summary{{
+			ID:      "web",
+			Names:   []string{"/web"},
+			Image:   "sha256:old",
+			ImageID: "sha256:old",
+			State:   "exited",
+			Status:  "Exited (0) 2 minutes ago",
+		}},
+		"GET /images/json": []image.Summary{{ID: "sha256:new", RepoTags: []string{"nginx:latest"}}},
+		"GET /containers/web/json": container.InspectResponse{
+			ContainerJSONBase: &container.ContainerJSONBase{ID: "web", Image: "sha256:old", State: &container.State{Status: "exited"}},
+			Config:            &container.Config{Image: "nginx"},
+		},
+	})
+
+	containers, err := c.ListContainers()
+	if err != nil {
+		t.Fatalf("ListContainers() error = %v", err)
+	}
+	if len(containers) != 1 {
+		t.Fatalf("ListContainers() = %+v, want one container", containers)
+	}
+	web := containers[0]
+	if web.Image != "nginx" || !web.Outdated {
+		t.Fatalf("web image = %q outdated = %v, want nginx and outdated", web.Image, web.Outdated)
+	}
+
+	reg := &fakeRegistry{
+		remote: map[string]string{"nginx:latest": "sha256:new"},
+		local:  map[string]string{"nginx:latest": "sha256:new"},
+	}
+	results := updateContainers(reg, containers, false, nil)
+	if len(results) != 1 || results[0].Status != UpdateStatusUpdated {
+		t.Fatalf("updateContainers() = %+v, want web updated", results)
+	}
+}
@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ResourceUsage holds the numeric resource usage of a running container from
+// a single stats sample.
+type ResourceUsage struct {
+	CPUPercent    float64
+	MemoryPercent float64
+	MemoryBytes   uint64
+	MemoryLimit   uint64
+	NetRxBytes    uint64
+	NetTxBytes    uint64
+	BlockRead     uint64
+	BlockWrite    uint64
+	PIDs          uint64
+}
+
+// PortMapping is one port of a container; PublicPort is zero when the port
+// is exposed but not published.
+type PortMapping struct {
+	IP          string
+	PrivatePort uint16
+	PublicPort  uint16
+	Type        string
+}
+
+// usageFromStats computes the usage figures shown by `docker stats`.
+func usageFromStats(payload container.StatsResponse) ResourceUsage {
+	cpuDelta := float64(payload.CPUStats.CPUUsage.TotalUsage - payload.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(payload.CPUStats.SystemUsage - payload.PreCPUStats.SystemUsage)
+	onlineCPUs := float64(payload.CPUStats.OnlineCPUs)
+	if onlineCPUs == 0 && len(payload.CPUStats.CPUUsage.PercpuUsage) > 0 {
+		onlineCPUs = float64(len(payload.CPUStats.CPUUsage.PercpuUsage))
+	}
+
+	usage := ResourceUsage{
+		MemoryBytes: payload.MemoryStats.Usage,
+		MemoryLimit: payload.MemoryStats.Limit,
+		PIDs:        payload.PidsStats.Current,
+	}
+	if cpuDelta > 0 && systemDelta > 0 && onlineCPUs > 0 {
+		usage.CPUPercent = (cpuDelta / systemDelta) * onlineCPUs * 100.0
+	}
+	if usage.MemoryLimit > 0 {
+		usage.MemoryPercent = float64(usage.MemoryBytes) / float64(usage.MemoryLimit) * 100.0
+	}
+	for _, netStats := range payload.Networks {
+		usage.NetRxBytes += netStats.RxBytes
+		usage.NetTxBytes += netStats.TxBytes
+	}
+	for _, entry := range payload.BlkioStats.IoServiceBytesRecursive {
+		switch strings.ToLower(entry.Op) {
+		case "read":
+			usage.BlockRead += entry.Value
+		case "write":
+			usage.BlockWrite += entry.Value
+		}
+	}
+	return usage
+}
+
+// portMappings converts the ports of a container list entry, ordered by
+// private port and protocol.
+func portMappings(ports []container.Port) []PortMapping {
+	if len(ports) == 0 {
+		return nil
+	}
+	mappings := make([]PortMapping, 0, len(ports))
+	for _, p := range ports {
+		mappings = append(mappings, PortMapping{IP: p.IP, PrivatePort: p.PrivatePort, PublicPort: p.PublicPort, Type: p.Type})
+	}
+	sort.Slice(mappings, func(i, j int) bool {
+		if mappings[i].PrivatePort != mappings[j].PrivatePort {
+			return mappings[i].PrivatePort < mappings[j].PrivatePort
+		}
+		if mappings[i].Type != mappings[j].Type {
+			return mappings[i].Type < mappings[j].Type
+		}
+		return mappings[i].PublicPort < mappings[j].PublicPort
+	})
+	return mappings
+}
@@ -0,0 +1,63 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestUsageFromStats(t *testing.T) {
+	t.Parallel()
+
+	var payload container.StatsResponse
+	payload.CPUStats.CPUUsage.TotalUsage = 300
+	payload.PreCPUStats.CPUUsage.TotalUsage = 100
+	payload.CPUStats.SystemUsage = 2000
+	payload.PreCPUStats.SystemUsage = 1000
+	payload.CPUStats.OnlineCPUs = 2
+	payload.MemoryStats.Usage = 256
+	payload.MemoryStats.Limit = 1024
+	payload.PidsStats.Current = 7
+	payload.Networks = map[string]container.NetworkStats{
+		"eth0": {RxBytes: 10, TxBytes: 20},
+		"eth1": {RxBytes: 1, TxBytes: 2},
+	}
+	payload.BlkioStats.IoServiceBytesRecursive = []container.BlkioStatEntry{
+		{Op: "Read", Value: 100},
+		{Op: "write", Value: 50},
+		{Op: "Total", Value: 150},
+	}
+
+	usage := usageFromStats(payload)
+	if usage.CPUPercent != 40 {
+		t.Fatalf("CPUPercent = %v, want 40", usage.CPUPercent)
+	}
+	if usage.MemoryPercent != 25 || usage.MemoryBytes != 256 {
+		t.Fatalf("memory = %v%% / %d bytes, want 25%% / 256", usage.MemoryPercent, usage.MemoryBytes)
+	}
+	if usage.NetRxBytes != 11 || usage.NetTxBytes != 22 {
+		t.Fatalf("net = %d/%d, want 11/22", usage.NetRxBytes, usage.NetTxBytes)
+	}
+	if usage.BlockRead != 100 || usage.BlockWrite != 50 {
+		t.Fatalf("block = %d/%d, want 100/50", usage.BlockRead, usage.BlockWrite)
+	}
+	if usage.PIDs != 7 {
+		t.Fatalf("PIDs = %d, want 7", usage.PIDs)
+	}
+}
+
+func TestPortMappings(t *testing.T) {
+	t.Parallel()
+
+	mappings := portMappings([]container.Port{
+		{PrivatePort: 443, Type: "tcp"},
+		{PrivatePort: 80, PublicPort: 8080, Type: "tcp", IP: "0.0.0.0"},
+		{PrivatePort: 80, Type: "udp"},
+	})
+	if len(mappings) != 3 || mappings[0].PublicPort != 8080 || mappings[1].Type != "udp" || mappings[2].PrivatePort != 443 {
+		t.Fatalf("portMappings() = %+v, want ordered by port and protocol", mappings)
+	}
+	if portMappings(nil) != nil {
+		t.Fatal("portMappings(nil) should be nil")
+	}
+}
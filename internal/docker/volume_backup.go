@@ -0,0 +1,311 @@
+package docker
+
+import (
+	"archive/tar"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+)
+
+const (
+	transferTimeout = 30 * time.Minute
+
+	// helperLabel marks the short-lived containers dock-it creates to reach
+	// volume contents, so leftovers can be identified.
+	helperLabel = "dock-it.helper"
+	// helperMountPath is where helper containers mount the volume.
+	helperMountPath = "/volume"
+)
+
+// VolumeTransferOptions controls a volume backup or restore.
+type VolumeTransferOptions struct {
+	Volume string
+	// Path is the tar file on the host to write to or read from.
+	Path string
+	// Image is a local image used for the helper container. The helper is
+	// never started, so any image works.
+	Image string
+	// StopUsers stops running containers that mount the volume for the
+	// duration of the transfer and starts them again afterwards.
+	StopUsers bool
+}
+
+// VolumeTransferResult summarizes a finished backup or restore.
+type VolumeTransferResult struct {
+	Path    string
+	Bytes   int64
+	Stopped []string // containers that were stopped and restarted
+}
+
+// TransferProgress reports the number of bytes transferred so far. total is
+// -1 when the size is not known in advance.
+type TransferProgress func(done, total int64)
+
+// BackupVolume streams the contents of a volume into a tar file on the host.
+// Entries in the archive are relative to the volume root.
+func (c *Client) BackupVolume(opts VolumeTransferOptions, progress TransferProgress) (result VolumeTransferResult, err error) {
+	path, err := filepath.Abs(expandHome(opts.Path))
+	if err != nil {
+		return result, err
+	}
+	result.Path = path
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	restart, stopped, err := c.stopVolumeUsers(ctx, opts)
+	result.Stopped = stopped
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = errors.Join(err, restart()) }()
+
+	helper, cleanup, err := c.createVolumeHelper(ctx, opts.Volume, opts.Image, true)
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = errors.Join(err, cleanup()) }()
+
+	stream, _, err := c.cli.CopyFromContainer(ctx, helper, helperMountPath)
+	if err != nil {
+		return result, fmt.Errorf("read volume: %w", err)
+	}
+	defer stream.Close()
+
+	partial := path + ".partial"
+	file, err := os.Create(partial)
+	if err != nil {
+		return result, err
+	}
+	counter := &countingWriter{w: file, progress: progress, total: -1}
+	if err := rebaseTar(counter, stream, strings.TrimPrefix(helperMountPath, "/")); err != nil {
+		file.Close()
+		os.Remove(partial)
+		return result, fmt.Errorf("write backup: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		os.Remove(partial)
+		return result, err
+	}
+	if err := os.Rename(partial, path); err != nil {
+		os.Remove(partial)
+		return result, err
+	}
+	result.Bytes = counter.n
+	return result, nil
+}
+
+// RestoreVolume extracts a tar file from the host into a volume. Existing
+// files with the same names are overwritten; other files are left alone.
+func (c *Client) RestoreVolume(opts VolumeTransferOptions, progress TransferProgress) (result VolumeTransferResult, err error) {
+	path, err := filepath.Abs(expandHome(opts.Path))
+	if err != nil {
+		return result, err
+	}
+	result.Path = path
+
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return result, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), transferTimeout)
+	defer cancel()
+
+	restart, stopped, err := c.stopVolumeUsers(ctx, opts)
+	result.Stopped = stopped
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = errors.Join(err, restart()) }()
+
+	helper, cleanup, err := c.createVolumeHelper(ctx, opts.Volume, opts.Image, false)
+	if err != nil {
+		return result, err
+	}
+	defer func() { err = errors.Join(err, cleanup()) }()
+
+	reader := &countingReader{r: file, progress: progress, total: info.Size()}
+	if err := c.cli.CopyToContainer(ctx, helper, helperMountPath, reader, container.CopyToContainerOptions{}); err != nil {
+		return result, fmt.Errorf("restore volume: %w", err)
+	}
+	result.Bytes = reader.n
+	return result, nil
+}
+
+// createVolumeHelper creates (but does not start) a container that mounts
+// the volume at helperMountPath. The returned cleanup removes it again.
+func (c *Client) createVolumeHelper(ctx context.Context, volumeName, image string, readOnly bool) (string, func() error, error) {
+	cfg, hostCfg, err := volumeHelperConfig(volumeName, image, readOnly)
+	if err != nil {
+		return "", nil, err
+	}
+	created, err := c.cli.ContainerCreate(ctx, cfg, hostCfg, nil, nil, "")
+	if err != nil {
+		return "", nil, fmt.Errorf("create helper container: %w", err)
+	}
+	cleanup := func() error {
+		rmCtx, cancel := timeoutCtx(defaultTimeout)
+		defer cancel()
+		if err := c.cli.ContainerRemove(rmCtx, created.ID, container.RemoveOptions{Force: true}); err != nil {
+			return fmt.Errorf("remove helper container: %w", err)
+		}
+		return nil
+	}
+	return created.ID, cleanup, nil
+}
+
+// volumeHelperConfig returns the configuration of a helper container that
+// mounts the volume at helperMountPath without network access.
+func volumeHelperConfig(volumeName, image string, readOnly bool) (*container.Config, *container.HostConfig, error) {
+	if strings.TrimSpace(image) == "" {
+		return nil, nil, errors.New("helper image is required")
+	}
+	cfg := &container.Config{
+		Image:  image,
+		Cmd:    []string{"true"},
+		Labels: map[string]string{helperLabel: "volume"},
+	}
+	hostCfg := &container.HostConfig{
+		Mounts: []mount.Mount{{
+			Type:     mount.TypeVolume,
+			Source:   volumeName,
+			Target:   helperMountPath,
+			ReadOnly: readOnly,
+		}},
+		NetworkMode: "none",
+	}
+	return cfg, hostCfg, nil
+}
+
+// stopVolumeUsers stops the running containers that mount the volume when
+// requested. The returned function starts them again.
+func (c *Client) stopVolumeUsers(ctx context.Context, opts VolumeTransferOptions) (func() error, []string, error) {
+	noop := func() error { return nil }
+	if !opts.StopUsers {
+		return noop, nil, nil
+	}
+
+	running, err := c.cli.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("volume", opts.Volume), filters.Arg("status", "running")),
+	})
+	if err != nil {
+		return noop, nil, fmt.Errorf("list volume users: %w", err)
+	}
+
+	var stopped []string
+	var ids []string
+	restart := func() error {
+		var errs []error
+		for i, id := range ids {
+			startCtx, cancel := timeoutCtx(lifecycleTimeout)
+			if err := c.cli.ContainerStart(startCtx, id, container.StartOptions{}); err != nil {
+				errs = append(errs, fmt.Errorf("restart %s: %w", stopped[i], err))
+			}
+			cancel()
+		}
+		return errors.Join(errs...)
+	}
+
+	for _, ctr := range running {
+		name := ctr.ID
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+		if err := c.cli.ContainerStop(ctx, ctr.ID, container.StopOptions{}); err != nil {
+			return restart, stopped, errors.Join(fmt.Errorf("stop %s: %w", name, err), restart())
+		}
+		ids = append(ids, ctr.ID)
+		stopped = append(stopped, name)
+	}
+	return restart, stopped, nil
+}
+
+// rebaseTar copies a tar stream, stripping prefix from every entry so the
+// archive is relative to that directory. The entry for prefix itself is
+// dropped.
+func rebaseTar(dst io.Writer, src io.Reader, prefix string) error {
+	prefix = strings.Trim(prefix, "/") + "/"
+	tr := tar.NewReader(src)
+	tw := tar.NewWriter(dst)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		name, ok := strings.CutPrefix(hdr.Name, prefix)
+		if !ok || name == "" {
+			continue
+		}
+		hdr.Name = name
+		if hdr.Typeflag == tar.TypeLink {
+			hdr.Linkname = strings.TrimPrefix(hdr.Linkname, prefix)
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+	return tw.Close()
+}
+
+func expandHome(path string) string {
+	path = strings.TrimSpace(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, strings.TrimPrefix(path, "~"))
+		}
+	}
+	return path
+}
+
+type countingWriter struct {
+	w        io.Writer
+	n        int64
+	total    int64
+	progress TransferProgress
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	if cw.progress != nil {
+		cw.progress(cw.n, cw.total)
+	}
+	return n, err
+}
+
+type countingReader struct {
+	r        io.Reader
+	n        int64
+	total    int64
+	progress TransferProgress
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	if cr.progress != nil {
+		cr.progress(cr.n, cr.total)
+	}
+	return n, err
+}
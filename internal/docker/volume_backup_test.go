@@ -0,0 +1,90 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"reflect"
+	"testing"
+)
+
+func TestRebaseTar(t *testing.T) {
+	t.Parallel()
+
+	var src bytes.Buffer
+	tw := tar.NewWriter(&src)
+	entries := []struct {
+		hdr  tar.Header
+		body string
+	}{
+		{hdr: tar.Header{Name: "volume/", Typeflag: tar.TypeDir, Mode: 0o755}},
+		{hdr: tar.Header{Name: "volume/app/", Typeflag: tar.TypeDir, Mode: 0o755}},
+		{hdr: tar.Header{Name: "volume/app/db.sqlite", Typeflag: tar.TypeReg, Mode: 0o644}, body: "data"},
+		{hdr: tar.Header{Name: "volume/app/db.link", Typeflag: tar.TypeLink, Linkname: "volume/app/db.sqlite"}},
+	}
+	for _, e := range entries {
+		hdr := e.hdr
+		hdr.Size = int64(len(e.body))
+		if err := tw.WriteHeader(&hdr); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(e.body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var dst bytes.Buffer
+	if err := rebaseTar(&dst, &src, "volume"); err != nil {
+		t.Fatalf("rebaseTar() error = %v", err)
+	}
+
+	var names []string
+	tr := tar.NewReader(&dst)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, hdr.Name)
+		switch hdr.Name {
+		case "app/db.sqlite":
+			body, _ := io.ReadAll(tr)
+			if string(body) != "data" {
+				t.Fatalf("file body = %q, want %q", body, "data")
+			}
+		case "app/db.link":
+			if hdr.Linkname != "app/db.sqlite" {
+				t.Fatalf("Linkname = %q, want app/db.sqlite", hdr.Linkname)
+			}
+		}
+	}
+
+	want := []string{"app/", "app/db.sqlite", "app/db.link"}
+	if !reflect.DeepEqual(names, want) {
+		t.Fatalf("entries = %v, want %v", names, want)
+	}
+}
+
+func TestCountingWriterReportsProgress(t *testing.T) {
+	t.Parallel()
+
+	var reports []int64
+	cw := &countingWriter{w: io.Discard, total: -1, progress: func(done, total int64) {
+		if total != -1 {
+			t.Fatalf("total = %d, want -1", total)
+		}
+		reports = append(reports, done)
+	}}
+	cw.Write([]byte("abc"))
+	cw.Write([]byte("de"))
+
+	if !reflect.DeepEqual(reports, []int64{3, 5}) || cw.n != 5 {
+		t.Fatalf("progress = %v (n=%d), want [3 5]", reports, cw.n)
+	}
+}
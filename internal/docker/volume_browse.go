@@ -0,0 +1,261 @@
+package docker
+
+import (
+	"archive/tar"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	cerrdefs "github.com/containerd/errdefs"
+	"github.com/docker/docker/api/types/container"
+)
+
+// VolumeEntry is a file or directory inside a volume.
+type VolumeEntry struct {
+	Name    string
+	Dir     bool
+	Size    int64 // size of a file; 0 for directories
+	ModTime time.Time
+	Mode    fs.FileMode
+	Link    string // symlink target
+}
+
+// browseHelperLifetime is how long a browse helper runs before it exits and
+// the daemon removes it, in case Close is never called.
+const browseHelperLifetime = time.Hour
+
+// VolumeBrowser gives read-only access to the files of a volume through a
+// running helper container that mounts it. Close removes the helper.
+type VolumeBrowser struct {
+	c       *Client
+	Volume  string
+	helper  string
+	cleanup func() error
+}
+
+// OpenVolumeBrowser starts the helper container used to read the volume.
+// Directories are listed with find and stat inside the helper, so image
+// must provide them along with sleep; busybox and alpine do. The helper is
+// auto-removed once it exits after browseHelperLifetime.
+func (c *Client) OpenVolumeBrowser(volumeName, image string) (*VolumeBrowser, error) {
+	ctx, cancel := timeoutCtx(lifecycleTimeout)
+	defer cancel()
+
+	cfg, hostCfg, err := volumeHelperConfig(volumeName, image, true)
+	if err != nil {
+		return nil, err
+	}
+	cfg.Cmd = []string{"sleep", strconv.Itoa(int(browseHelperLifetime.Seconds()))}
+	hostCfg.AutoRemove = true
+
+	created, err := c.cli.ContainerCreate(ctx, cfg, hostCfg, nil, nil, "")
+	if err != nil {
+		return nil, fmt.Errorf("create helper container: %w", err)
+	}
+	b := &VolumeBrowser{c: c, Volume: volumeName, helper: created.ID}
+	b.cleanup = func() error {
+		rmCtx, cancel := timeoutCtx(defaultTimeout)
+		defer cancel()
+		err := c.cli.ContainerRemove(rmCtx, created.ID, container.RemoveOptions{Force: true})
+		if err != nil && !cerrdefs.IsNotFound(err) {
+			return fmt.Errorf("remove helper container: %w", err)
+		}
+		return nil
+	}
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return nil, errors.Join(fmt.Errorf("start helper container (image %s needs sleep, find and stat): %w", image, err), b.Close())
+	}
+	return b, nil
+}
+
+// List returns the entries of dir (relative to the volume root), directories
+// first. Only metadata is read; file contents never leave the helper.
+func (b *VolumeBrowser) List(dir string) ([]VolumeEntry, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	dirPath := b.containerPath(dir)
+	stat, err := b.c.cli.ContainerStatPath(ctx, b.helper, dirPath)
+	if err != nil {
+		return nil, err
+	}
+	if !stat.Mode.IsDir() {
+		return nil, errors.New("not a directory")
+	}
+
+	cmd := []string{"find", dirPath, "-mindepth", "1", "-maxdepth", "1", "-exec", "stat", "-c", statFormat, "{}", "+"}
+	out, code, err := b.c.execCapture(ctx, b.helper, cmd)
+	if err != nil {
+		return nil, err
+	}
+	entries := parseStatLines(out, dirPath)
+	if code != 0 && len(entries) == 0 {
+		return nil, fmt.Errorf("list failed (exit %d): %s", code, strings.TrimSpace(out))
+	}
+
+	for i := range entries {
+		if entries[i].Mode&fs.ModeSymlink == 0 {
+			continue
+		}
+		if link, err := b.c.cli.ContainerStatPath(ctx, b.helper, path.Join(dirPath, entries[i].Name)); err == nil {
+			entries[i].Link = link.LinkTarget
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Dir != entries[j].Dir {
+			return entries[i].Dir
+		}
+		return entries[i].Name < entries[j].Name
+	})
+	return entries, nil
+}
+
+// Download copies a single file from the volume to dest on the host. When
+// dest is an existing directory the file keeps its name. An existing file
+// is never overwritten.
+func (b *VolumeBrowser) Download(file, dest string) (string, int64, error) {
+	dest, err := filepath.Abs(expandHome(dest))
+	if err != nil {
+		return "", 0, err
+	}
+	if info, err := os.Stat(dest); err == nil && info.IsDir() {
+		dest = filepath.Join(dest, path.Base(file))
+	}
+	if _, err := os.Lstat(dest); err == nil {
+		return dest, 0, fmt.Errorf("%s already exists", dest)
+	}
+
+	ctx, cancel := timeoutCtx(transferTimeout)
+	defer cancel()
+
+	stream, stat, err := b.c.cli.CopyFromContainer(ctx, b.helper, b.containerPath(file))
+	if err != nil {
+		return dest, 0, err
+	}
+	defer stream.Close()
+	if !stat.Mode.IsRegular() {
+		return dest, 0, fmt.Errorf("%s is not a regular file", file)
+	}
+
+	// O_EXCL also refuses a file created since the check above.
+	out, err := os.OpenFile(dest, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o666)
+	if err != nil {
+		if errors.Is(err, fs.ErrExist) {
+			return dest, 0, fmt.Errorf("%s already exists", dest)
+		}
+		return dest, 0, err
+	}
+	n, err := extractTarFile(out, stream)
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(dest)
+		return dest, 0, err
+	}
+	return dest, n, nil
+}
+
+// Close removes the helper container.
+func (b *VolumeBrowser) Close() error {
+	if b == nil || b.cleanup == nil {
+		return nil
+	}
+	err := b.cleanup()
+	b.cleanup = nil
+	return err
+}
+
+func (b *VolumeBrowser) containerPath(rel string) string {
+	return path.Join(helperMountPath, path.Clean("/"+rel))
+}
+
+// statFormat makes stat print the raw mode in hex, the size, the
+// modification time in Unix seconds and the path of each entry.
+const statFormat = "%f %s %Y %n"
+
+// parseStatLines parses the statFormat output for the children of dir.
+// Lines that are not about a child of dir, such as find's permission
+// errors, are skipped.
+func parseStatLines(out, dir string) []VolumeEntry {
+	var entries []VolumeEntry
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.SplitN(line, " ", 4)
+		if len(fields) != 4 {
+			continue
+		}
+		name, ok := strings.CutPrefix(fields[3], strings.TrimSuffix(dir, "/")+"/")
+		if !ok || name == "" || strings.Contains(name, "/") {
+			continue
+		}
+		rawMode, err1 := strconv.ParseUint(fields[0], 16, 32)
+		size, err2 := strconv.ParseInt(fields[1], 10, 64)
+		mtime, err3 := strconv.ParseInt(fields[2], 10, 64)
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+		mode := unixFileMode(uint32(rawMode))
+		entry := VolumeEntry{Name: name, Dir: mode.IsDir(), ModTime: time.Unix(mtime, 0), Mode: mode}
+		if mode.IsRegular() {
+			entry.Size = size
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// unixFileMode converts a raw st_mode into an fs.FileMode.
+func unixFileMode(m uint32) fs.FileMode {
+	mode := fs.FileMode(m & 0o777)
+	switch m & 0o170000 {
+	case 0o040000:
+		mode |= fs.ModeDir
+	case 0o120000:
+		mode |= fs.ModeSymlink
+	case 0o010000:
+		mode |= fs.ModeNamedPipe
+	case 0o140000:
+		mode |= fs.ModeSocket
+	case 0o020000:
+		mode |= fs.ModeDevice | fs.ModeCharDevice
+	case 0o060000:
+		mode |= fs.ModeDevice
+	}
+	if m&0o4000 != 0 {
+		mode |= fs.ModeSetuid
+	}
+	if m&0o2000 != 0 {
+		mode |= fs.ModeSetgid
+	}
+	if m&0o1000 != 0 {
+		mode |= fs.ModeSticky
+	}
+	return mode
+}
+
+// extractTarFile writes the body of the first regular file in the archive
+// to w.
+func extractTarFile(w io.Writer, r io.Reader) (int64, error) {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return 0, errors.New("archive contains no regular file")
+		}
+		if err != nil {
+			return 0, err
+		}
+		if hdr.Typeflag == tar.TypeReg {
+			return io.Copy(w, tr)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func buildTar(t *testing.T, files map[string]string, dirs ...string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	modTime := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	for _, d := range dirs {
+		if err := tw.WriteHeader(&tar.Header{Name: d, Typeflag: tar.TypeDir, Mode: 0o755, ModTime: modTime}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		body := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(body)), ModTime: modTime}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestParseStatLines(t *testing.T) {
+	t.Parallel()
+
+	out := strings.Join([]string{
+		"41ed 4096 1714564800 /volume/data/logs",
+		"81a4 3 1714564800 /volume/data/config file.yml",
+		"a1ff 11 1714564800 /volume/data/current",
+		"find: /volume/data/private: Permission denied",
+		"81a4 5 1714564800 /volume/other/x",
+		"",
+	}, "\n")
+
+	entries := parseStatLines(out, "/volume/data")
+	type summary struct {
+		Name string
+		Dir  bool
+		Size int64
+		Mode fs.FileMode
+	}
+	var got []summary
+	for _, e := range entries {
+		got = append(got, summary{e.Name, e.Dir, e.Size, e.Mode})
+		if !e.ModTime.Equal(time.Unix(1714564800, 0)) {
+			t.Fatalf("entry %s modified at %v", e.Name, e.ModTime)
+		}
+	}
+	want := []summary{
+		{"logs", true, 0, fs.ModeDir | 0o755},
+		{"config file.yml", false, 3, 0o644},
+		{"current", false, 0, fs.ModeSymlink | 0o777},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseStatLines() = %+v, want %+v", got, want)
+	}
+}
+
+func TestExtractTarFile(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	n, err := extractTarFile(&out, buildTar(t, map[string]string{"config.yml": "hello"}))
+	if err != nil || n != 5 || out.String() != "hello" {
+		t.Fatalf("extractTarFile() = %d, %v, body %q", n, err, out.String())
+	}
+}
+
+func TestDownloadRefusesExistingFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "app.conf")
+	if err := os.WriteFile(dest, []byte("keep"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	b := &VolumeBrowser{c: newFakeClient(t, fakeDaemon{}), helper: "helper"}
+	for _, target := range []string{dest, dir} {
+		if _, _, err := b.Download("etc/app.conf", target); err == nil || !strings.Contains(err.Error(), "already exists") {
+			t.Fatalf("Download(%q) error = %v, want already exists", target, err)
+		}
+	}
+	if got, err := os.ReadFile(dest); err != nil || string(got) != "keep" {
+		t.Fatalf("existing file = %q, %v, want it untouched", got, err)
+	}
+}
@@ -0,0 +1,265 @@
+package docker
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// anonymousVolumeLabel is set by the daemon on volumes it creates for
+// unnamed mounts and image VOLUME declarations.
+const anonymousVolumeLabel = "com.docker.volume.anonymous"
+
+var (
+	generatedVolumeName = regexp.MustCompile(`^[0-9a-f]{64}$`)
+	// volumeNamePattern mirrors the daemon's restriction on volume names.
+	volumeNamePattern = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]+$`)
+)
+
+// VolumeUser is a container that mounts a volume.
+type VolumeUser struct {
+	ContainerID string
+	Name        string
+	State       string
+	Destination string
+	ReadOnly    bool
+}
+
+// MountInfo describes one mount of a container.
+type MountInfo struct {
+	Type        string
+	Name        string // volume name, empty for binds and tmpfs
+	Source      string
+	Destination string
+	RW          bool
+	Mode        string
+	Propagation string
+}
+
+// ContainerMounts returns the mounts of a container in destination order.
+func (c *Client) ContainerMounts(id string) ([]MountInfo, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	details, err := c.cli.ContainerInspect(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return mountInfos(details.Mounts), nil
+}
+
+func mountInfos(points []container.MountPoint) []MountInfo {
+	mounts := make([]MountInfo, 0, len(points))
+	for _, mp := range points {
+		mounts = append(mounts, MountInfo{
+			Type:        string(mp.Type),
+			Name:        mp.Name,
+			Source:      mp.Source,
+			Destination: mp.Destination,
+			RW:          mp.RW,
+			Mode:        mp.Mode,
+			Propagation: string(mp.Propagation),
+		})
+	}
+	sort.Slice(mounts, func(i, j int) bool {
+		return mounts[i].Destination < mounts[j].Destination
+	})
+	return mounts
+}
+
+// volumeUsers groups containers by the name of every volume they mount.
+func volumeUsers(containers []container.Summary) map[string][]VolumeUser {
+	users := make(map[string][]VolumeUser)
+	for _, ctr := range containers {
+		name := "<none>"
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+		for _, mp := range ctr.Mounts {
+			if mp.Type != mount.TypeVolume || mp.Name == "" {
+				continue
+			}
+			users[mp.Name] = append(users[mp.Name], VolumeUser{
+				ContainerID: ctr.ID,
+				Name:        name,
+				State:       ctr.State,
+				Destination: mp.Destination,
+				ReadOnly:    !mp.RW,
+			})
+		}
+	}
+	for vol := range users {
+		sort.Slice(users[vol], func(i, j int) bool {
+			return users[vol][i].Name < users[vol][j].Name
+		})
+	}
+	return users
+}
+
+// isAnonymousVolume reports whether a volume was created without a name.
+// Older daemons do not set the anonymous label, so a generated 64-character
+// hex name is treated as anonymous too.
+func isAnonymousVolume(name string, labels map[string]string) bool {
+	if _, ok := labels[anonymousVolumeLabel]; ok {
+		return true
+	}
+	return generatedVolumeName.MatchString(name)
+}
+
+// FormatVolumeUsers renders the containers using a volume for table display.
+func FormatVolumeUsers(users []VolumeUser) string {
+	if len(users) == 0 {
+		return "-"
+	}
+	names := make([]string, len(users))
+	for i, u := range users {
+		names[i] = u.Name
+	}
+	if len(names) > 3 {
+		return fmt.Sprintf("%s +%d", strings.Join(names[:3], ", "), len(names)-3)
+	}
+	return strings.Join(names, ", ")
+}
+
+// localDriverOptions are the options accepted by the built-in local driver.
+var localDriverOptions = map[string]struct{}{"type": {}, "device": {}, "o": {}, "size": {}}
+
+// VolumeSpec describes a volume to create.
+type VolumeSpec struct {
+	Name    string
+	Driver  string
+	Options map[string]string
+	Labels  map[string]string
+}
+
+// VolumeInput is the textual form of VolumeSpec as edited in the UI. Options
+// and labels are comma separated key=value lists.
+type VolumeInput struct {
+	Name    string
+	Driver  string
+	Options string
+	Labels  string
+}
+
+// Parse validates the input and converts it into a VolumeSpec. Options for
+// the local driver are checked against the mount types it supports.
+func (in VolumeInput) Parse() (VolumeSpec, error) {
+	var errs []error
+	spec := VolumeSpec{
+		Name:   strings.TrimSpace(in.Name),
+		Driver: strings.TrimSpace(in.Driver),
+	}
+	if spec.Driver == "" {
+		spec.Driver = "local"
+	}
+	if spec.Name != "" && !volumeNamePattern.MatchString(spec.Name) {
+		errs = append(errs, fmt.Errorf("name: invalid volume name %q", spec.Name))
+	}
+
+	options, err := parseKeyValues(in.Options)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("options: %w", err))
+	}
+	spec.Options = options
+	if spec.Driver == "local" {
+		errs = append(errs, validateLocalOptions(options)...)
+	}
+
+	labels, err := parseKeyValues(in.Labels)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("labels: %w", err))
+	}
+	spec.Labels = labels
+
+	return spec, errors.Join(errs...)
+}
+
+// validateLocalOptions checks the type/device/o options of the local driver
+// the way `mount` would interpret them.
+func validateLocalOptions(opts map[string]string) []error {
+	var errs []error
+	for _, key := range sortedKeys(opts) {
+		if _, ok := localDriverOptions[key]; !ok {
+			errs = append(errs, fmt.Errorf("options: unknown local driver option %q (valid: type, device, o, size)", key))
+		}
+	}
+
+	typ, device, o := opts["type"], opts["device"], opts["o"]
+	if typ == "" && device == "" && o == "" {
+		return errs
+	}
+	if typ == "" {
+		errs = append(errs, errors.New("options: type is required when device or o is set"))
+		return errs
+	}
+
+	mountOpts := make(map[string]string)
+	for _, opt := range strings.Split(o, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(opt), "=")
+		if key != "" {
+			mountOpts[key] = value
+		}
+	}
+
+	switch typ {
+	case "tmpfs":
+		if device != "" && device != "tmpfs" {
+			errs = append(errs, fmt.Errorf("options: tmpfs device must be empty or \"tmpfs\", got %q", device))
+		}
+	case "nfs", "nfs4":
+		if _, ok := mountOpts["addr"]; !ok {
+			errs = append(errs, fmt.Errorf("options: %s mounts need o=addr=<server>", typ))
+		}
+		if !strings.HasPrefix(device, ":") {
+			errs = append(errs, fmt.Errorf("options: %s device must be the export path prefixed with ':' (e.g. :/exports/data)", typ))
+		}
+	case "none":
+		if _, ok := mountOpts["bind"]; !ok {
+			errs = append(errs, errors.New("options: type=none requires o=bind"))
+		}
+		if !strings.HasPrefix(device, "/") {
+			errs = append(errs, fmt.Errorf("options: bind device must be an absolute host path, got %q", device))
+		}
+	default:
+		if device == "" {
+			errs = append(errs, fmt.Errorf("options: device is required for type=%s", typ))
+		}
+	}
+	return errs
+}
+
+// CreateVolume creates a volume and returns its name, which the daemon
+// generates when spec.Name is empty.
+func (c *Client) CreateVolume(spec VolumeSpec) (string, error) {
+	ctx, cancel := timeoutCtx(defaultTimeout)
+	defer cancel()
+
+	vol, err := c.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       spec.Name,
+		Driver:     spec.Driver,
+		DriverOpts: spec.Options,
+		Labels:     spec.Labels,
+	})
+	if err != nil {
+		return "", err
+	}
+	return vol.Name, nil
+}
+
+// FormatLabels renders labels as a sorted key=value list for table display.
+func FormatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	pairs := make([]string, 0, len(labels))
+	for _, key := range sortedKeys(labels) {
+		pairs = append(pairs, key+"="+labels[key])
+	}
+	return strings.Join(pairs, ", ")
+}
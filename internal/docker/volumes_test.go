@@ -0,0 +1,155 @@
+package docker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+)
+
+func TestVolumeUsers(t *testing.T) {
+	t.Parallel()
+
+	containers := []container.Summary{
+		{
+			ID:    "b",
+			Names: []string{"/web"},
+			State: "running",
+			Mounts: []container.MountPoint{
+				{Type: mount.TypeVolume, Name: "data", Destination: "/data", RW: true},
+				{Type: mount.TypeBind, Source: "/etc/app", Destination: "/etc/app"},
+			},
+		},
+		{
+			ID:     "a",
+			Names:  []string{"/backup"},
+			State:  "exited",
+			Mounts: []container.MountPoint{{Type: mount.TypeVolume, Name: "data", Destination: "/src", RW: false}},
+		},
+	}
+
+	users := volumeUsers(containers)
+	if len(users) != 1 {
+		t.Fatalf("volumeUsers() = %+v, want only the data volume", users)
+	}
+	data := users["data"]
+	if len(data) != 2 || data[0].Name != "backup" || data[1].Name != "web" {
+		t.Fatalf("data users = %+v, want backup and web sorted by name", data)
+	}
+	if !data[0].ReadOnly || data[1].ReadOnly {
+		t.Fatalf("read-only flags = %v/%v, want true/false", data[0].ReadOnly, data[1].ReadOnly)
+	}
+}
+
+func TestIsAnonymousVolume(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		volume string
+		labels map[string]string
+		want   bool
+	}{
+		{name: "named", volume: "pgdata", want: false},
+		{name: "generated name", volume: strings.Repeat("ab", 32), want: true},
+		{name: "labelled", volume: "custom", labels: map[string]string{anonymousVolumeLabel: ""}, want: true},
+		{name: "short hex", volume: "abcdef", want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := isAnonymousVolume(tt.volume, tt.labels); got != tt.want {
+				t.Fatalf("isAnonymousVolume(%q) = %v, want %v", tt.volume, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMountInfos(t *testing.T) {
+	t.Parallel()
+
+	mounts := mountInfos([]container.MountPoint{
+		{Type: mount.TypeVolume, Name: "data", Source: "/var/lib/docker/volumes/data/_data", Destination: "/var/lib/data", RW: true, Mode: "z"},
+		{Type: mount.TypeBind, Source: "/srv/config", Destination: "/etc/app", Propagation: mount.PropagationRPrivate},
+	})
+	if len(mounts) != 2 || mounts[0].Destination != "/etc/app" {
+		t.Fatalf("mountInfos() = %+v, want sorted by destination", mounts)
+	}
+	if mounts[0].Type != "bind" || mounts[0].RW || mounts[0].Propagation != "rprivate" {
+		t.Fatalf("bind mount = %+v", mounts[0])
+	}
+	if mounts[1].Name != "data" || !mounts[1].RW {
+		t.Fatalf("volume mount = %+v", mounts[1])
+	}
+}
+
+func TestFormatVolumeUsers(t *testing.T) {
+	t.Parallel()
+
+	users := []VolumeUser{{Name: "a"}, {Name: "b"}, {Name: "c"}, {Name: "d"}, {Name: "e"}}
+	tests := []struct {
+		users []VolumeUser
+		want  string
+	}{
+		{users: nil, want: "-"},
+		{users: users[:2], want: "a, b"},
+		{users: users, want: "a, b, c +2"},
+	}
+	for _, tt := range tests {
+		if got := FormatVolumeUsers(tt.users); got != tt.want {
+			t.Fatalf("FormatVolumeUsers(%d users) = %q, want %q", len(tt.users), got, tt.want)
+		}
+	}
+}
+
+func TestVolumeInputParse(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   VolumeInput
+		wantErr bool
+	}{
+		{name: "generated name", input: VolumeInput{}},
+		{name: "labels", input: VolumeInput{Name: "pgdata", Labels: "team=db, backup=daily"}},
+		{name: "tmpfs", input: VolumeInput{Name: "scratch", Options: "type=tmpfs, device=tmpfs, o=size=100m"}},
+		{name: "nfs", input: VolumeInput{Name: "share", Options: `type=nfs, device=:/exports/data, o=addr=10.0.0.5\,rw`}},
+		{name: "bind", input: VolumeInput{Name: "host", Options: "type=none, device=/srv/data, o=bind"}},
+		{name: "third-party driver options", input: VolumeInput{Name: "vol", Driver: "rexray", Options: "size=10"}},
+		{name: "invalid name", input: VolumeInput{Name: "my/vol"}, wantErr: true},
+		{name: "unknown local option", input: VolumeInput{Name: "vol", Options: "mode=fast"}, wantErr: true},
+		{name: "device without type", input: VolumeInput{Name: "vol", Options: "device=/srv"}, wantErr: true},
+		{name: "nfs without addr", input: VolumeInput{Name: "vol", Options: "type=nfs, device=:/exports"}, wantErr: true},
+		{name: "bind with relative path", input: VolumeInput{Name: "vol", Options: "type=none, device=srv, o=bind"}, wantErr: true},
+		{name: "bind without o=bind", input: VolumeInput{Name: "vol", Options: "type=none, device=/srv"}, wantErr: true},
+		{name: "bad label", input: VolumeInput{Name: "vol", Labels: "=v"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			spec, err := tt.input.Parse()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && spec.Driver == "" {
+				t.Fatal("Parse() should default the driver")
+			}
+		})
+	}
+}
+
+func TestFormatLabels(t *testing.T) {
+	t.Parallel()
+
+	if got := FormatLabels(nil); got != "-" {
+		t.Fatalf("FormatLabels(nil) = %q, want -", got)
+	}
+	if got := FormatLabels(map[string]string{"team": "db", "env": "prod"}); got != "env=prod, team=db" {
+		t.Fatalf("FormatLabels() = %q", got)
+	}
+}
@@ -0,0 +1,151 @@
+package filter
+
+import (
+	"sort"
+	"strings"
+)
+
+// CompletionData holds the values seen in the rows currently loaded in a
+// view, used to complete criterion values.
+type CompletionData struct {
+	Values      map[FilterType][]string // e.g. names, drivers, image tags
+	LabelValues map[string][]string     // label key -> values
+}
+
+// Complete returns completions for the last term of input in a view. Each
+// option replaces input[start:]. The term being completed is a field name
+// (or label.<key>), an operator after a complete field name, or a value
+// after an operator. Fuzzy searches are not completed.
+func Complete(input string, resource Resource, data CompletionData) (int, []string) {
+	if strings.HasPrefix(strings.TrimSpace(input), FuzzyPrefix) {
+		return len(input), nil
+	}
+	if strings.Count(input, `"`)%2 == 1 || strings.Count(input, "'")%2 == 1 {
+		return len(input), nil
+	}
+	start := termStart(input)
+	term := input[start:]
+
+	if i := strings.IndexAny(term, "=!<>~"); i > 0 {
+		return start, completeValue(term[:i], term[i:], resource, data)
+	}
+	return start, completeField(term, resource, data)
+}
+
+// termStart finds where the last term of input begins: after whitespace, a
+// comma, a parenthesis, && or ||, or a ! that negates the term rather than
+// following a field name as part of an operator.
+func termStart(input string) int {
+	for i := len(input) - 1; i >= 0; i-- {
+		switch input[i] {
+		case ' ', '\t', ',', '(', ')', '&', '|':
+			return i + 1
+		case '!':
+			if i == 0 || strings.IndexByte(" \t,(&|!", input[i-1]) >= 0 {
+				return i + 1
+			}
+		}
+	}
+	return 0
+}
+
+func completeField(term string, resource Resource, data CompletionData) []string {
+	if key, ok := strings.CutPrefix(term, string(FilterLabel)+"."); ok {
+		var options []string
+		for _, k := range sortedLabelKeys(data) {
+			if strings.HasPrefix(k, key) {
+				options = append(options, string(FilterLabel)+"."+k)
+			}
+		}
+		if len(options) == 1 && options[0] == term {
+			return operatorOptions(term, ValueLabel.Ops(), "")
+		}
+		return options
+	}
+
+	var options []string
+	for _, f := range Fields(resource) {
+		if f.Type == FilterInspect {
+			continue
+		}
+		name := string(f.Type)
+		if f.Type == FilterLabel {
+			name += "."
+		}
+		if name == term {
+			options = append(options, operatorOptions(term, f.Kind.Ops(), "")...)
+			continue
+		}
+		if strings.HasPrefix(name, strings.ToLower(term)) {
+			options = append(options, name)
+		}
+	}
+	return options
+}
+
+func completeValue(field, rest string, resource Resource, data CompletionData) []string {
+	f, ok := lookupField(resource, fieldType(field))
+	if !ok {
+		return nil
+	}
+	op := matchOperator(rest)
+	if op == "" {
+		return operatorOptions(field, f.Kind.Ops(), rest)
+	}
+	prefix := strings.ToLower(strings.TrimLeft(rest[len(op):], " "))
+
+	var candidates []string
+	if key, ok := strings.CutPrefix(field, string(FilterLabel)+"."); ok {
+		candidates = data.LabelValues[key]
+	} else {
+		candidates = append(append(candidates, f.Values...), data.Values[f.Type]...)
+	}
+	if f.Kind == ValueTime {
+		candidates = append(candidates, timeKeywords...)
+	}
+
+	seen := make(map[string]struct{})
+	var options []string
+	for _, v := range candidates {
+		if v == "" || !strings.HasPrefix(strings.ToLower(v), prefix) {
+			continue
+		}
+		option := field + string(op) + quoteValue(v)
+		if _, dup := seen[option]; !dup {
+			seen[option] = struct{}{}
+			options = append(options, option)
+		}
+	}
+	sort.Strings(options)
+	return options
+}
+
+func fieldType(field string) FilterType {
+	if strings.HasPrefix(field, string(FilterLabel)+".") {
+		return FilterLabel
+	}
+	if strings.HasPrefix(field, ".") {
+		return FilterInspect
+	}
+	return FilterType(field)
+}
+
+// operatorOptions lists field followed by each operator starting with typed.
+func operatorOptions(field string, ops []ComparisonOp, typed string) []string {
+	var options []string
+	for _, op := range ops {
+		if strings.HasPrefix(string(op), typed) {
+			options = append(options, field+string(op))
+		}
+	}
+	return options
+}
+
+func sortedLabelKeys(data CompletionData) []string {
+	keys := make([]string, 0, len(data.LabelValues))
+	for k := range data.LabelValues {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,61 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestComplete(t *testing.T) {
+	data := CompletionData{
+		Values: map[FilterType][]string{
+			FilterName:  {"web", "worker", "db", "web"},
+			FilterState: {"running"},
+		},
+		LabelValues: map[string][]string{
+			"com.example.team": {"payments", "orders"},
+			"tier":             {"web"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		input     string
+		wantStart int
+		want      []string
+	}{
+		{"field prefix", "sta", 0, []string{"state", "status", "started"}},
+		{"unique field", "exi", 0, []string{"exitcode"}},
+		{"operators for field", "oom", 0, []string{"oom=", "oom!="}},
+		{"partial operator", "name!", 0, []string{"name!=", "name!~"}},
+		{"enum values", "state=r", 0, []string{"state=removing", "state=restarting", "state=running"}},
+		{"loaded values deduplicated", "state=running and name=w", 18, []string{"name=web", "name=worker"}},
+		{"after negation", "!na", 1, []string{"name"}},
+		{"label keys", "label.com", 0, []string{"label.com.example.team"}},
+		{"label values", "(label.com.example.team=p", 1, []string{"label.com.example.team=payments"}},
+		{"unknown field", "bogus=", 0, nil},
+		{"open quote", `name="we`, 8, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, got := Complete(tt.input, ResourceContainers, data)
+			if start != tt.wantStart {
+				t.Errorf("Complete(%q) start = %d, want %d", tt.input, start, tt.wantStart)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Complete(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompleteFieldsPerView(t *testing.T) {
+	_, got := Complete("s", ResourceImages, CompletionData{})
+	if !reflect.DeepEqual(got, []string{"size"}) {
+		t.Errorf("Complete(s) for images = %v, want [size]", got)
+	}
+	_, got = Complete("s", ResourceNetworks, CompletionData{})
+	if !reflect.DeepEqual(got, []string{"scope", "subnet"}) {
+		t.Errorf("Complete(s) for networks = %v, want [scope subnet]", got)
+	}
+}
@@ -22,6 +22,56 @@ const (
 	FilterSize   FilterType = "size"
 	FilterDriver FilterType = "driver"
 	FilterScope  FilterType = "scope"
+
+	// Event times, compared with dates, timestamps or ages
+	FilterCreated  FilterType = "created"
+	FilterStarted  FilterType = "started"
+	FilterFinished FilterType = "finished"
+
+	FilterExitCode  FilterType = "exitcode"
+	FilterOOM       FilterType = "oom"
+	FilterRestarts  FilterType = "restarts"
+	FilterCrashLoop FilterType = "crashloop"
+	FilterOutdated  FilterType = "outdated"
+
+	FilterContainers FilterType = "containers"
+	FilterSubnet     FilterType = "subnet"
+	FilterInternal   FilterType = "internal"
+	FilterAttachable FilterType = "attachable"
+
+	FilterUsed      FilterType = "used"
+	FilterAnonymous FilterType = "anonymous"
+
+	// FilterLabel matches a single label; it is written as label.<key>.
+	FilterLabel FilterType = "label"
+
+	// Resource usage of running containers, from the last stats sample.
+	FilterCPU        FilterType = "cpu"    // percent
+	FilterMem        FilterType = "mem"    // percent of the memory limit
+	FilterMemory     FilterType = "memory" // bytes
+	FilterNetRx      FilterType = "netrx"
+	FilterNetTx      FilterType = "nettx"
+	FilterNetIO      FilterType = "netio" // rx + tx bytes
+	FilterBlockRead  FilterType = "blockread"
+	FilterBlockWrite FilterType = "blockwrite"
+	FilterBlockIO    FilterType = "blockio" // read + write bytes
+	FilterPIDs       FilterType = "pids"
+
+	// FilterPort matches published and private container ports.
+	FilterPort FilterType = "port"
+
+	// Inspect path criteria (.HostConfig.Privileged=true); the path is the
+	// criterion's Key
+	FilterInspect FilterType = "inspect"
+
+	// Container references; = and != compare names exactly and
+	// case-sensitively, as the daemon's filters do
+	FilterImage   FilterType = "image"
+	FilterNetwork FilterType = "network"
+	FilterVolume  FilterType = "volume"
+
+	// Image-specific filters
+	FilterDangling FilterType = "dangling"
 )
 
 // ComparisonOp represents comparison operators for filters.
@@ -37,6 +87,8 @@ const (
 	OpContains     ComparisonOp = "~"
 	OpNotContains  ComparisonOp = "!~"
 	OpRegex        ComparisonOp = "=~"
+	// OpExists is produced by a bare label.<key> and has no textual form.
+	OpExists ComparisonOp = "exists"
 )
 
 // Criterion represents a single filter criterion.
@@ -44,17 +96,40 @@ type Criterion struct {
 	Type     FilterType
 	Op       ComparisonOp
 	Value    string
-	Duration time.Duration // For age filters
-	Bytes    int64         // For size filters
-	Regex    *regexp.Regexp
+	Duration time.Duration // For age filters, and ages given to time fields
+	// DurationMax is the upper end of a duration range (age=1h..1d); it
+	// equals Duration otherwise.
+	DurationMax time.Duration
+	From, To    time.Time // For absolute time values, the span [From, To) they name; day keywords are resolved again when matching
+	Range       bool      // The value is a lo..hi range
+	Bytes       int64     // For size filters (size, memory, netio, blockio, ...)
+	Number      float64   // For numeric filters (exitcode, restarts, containers, cpu, mem, pids, port)
+	Bool        bool      // For boolean filters (oom, crashloop, outdated, internal, attachable, used, anonymous)
+	Key         string    // For label filters, the label key; for inspect filters, the path
+	Regex       *regexp.Regexp
+
+	path []pathStep // For inspect filters, the parsed Key
 }
 
-// Filter holds multiple filter criteria and a simple search term.
+// Filter is a parsed filter expression. Criteria and SearchTerm mirror the
+// expression when it is a plain list of criteria or a single search term,
+// the forms the filter bar has always accepted.
 type Filter struct {
 	Criteria   []Criterion
 	SearchTerm string // Simple search across all fields like k9s
+	Expr       Node   // Full expression; nil when the filter is empty
+	Fuzzy      string // Fuzzy search pattern (input starting with '?'), lower-cased
+
+	// Inspect provides inspect data for path criteria; they never match
+	// without it.
+	Inspect InspectSource
+
+	highlights []highlighter // built on first use by MatchRanges
 }
 
+// FuzzyPrefix switches the filter input to fuzzy search.
+const FuzzyPrefix = "?"
+
 // New creates a new empty filter.
 func New() *Filter {
 	return &Filter{
@@ -63,100 +138,156 @@ func New() *Filter {
 	}
 }
 
-// ParseFilter parses a filter string and returns a Filter.
-// If the input contains operators (=, >, <, ~), it's treated as advanced filter criteria.
-// Otherwise, it's treated as a simple search term that matches across all fields (like k9s).
-// Supported advanced formats:
+// ParseFilter parses a filter expression and returns a Filter.
+// Criteria have the form <field><op><value>; any other word or quoted string
+// is a search term that matches across all fields (like k9s), and
+// consecutive words form one phrase. Terms combine with and/&&, or/||,
+// not/! and parentheses; commas and plain juxtaposition mean AND, and AND
+// binds tighter than OR:
+//   - (state=running or state=restarting) and not name~test
+//   - redis, age>1h    (search term plus criterion)
+//   - "my app" || label.team=web
+//
+// Supported criteria:
 //   - age>1h, age<30m
 //   - status=running, state=exited
 //   - name~redis, name=mycontainer
 //   - tag~ubuntu, tag=latest
 //   - size>100MB
 //   - driver=bridge
+//   - exitcode!=0, restarts>3
+//   - oom=true, crashloop=true, outdated=true
+//   - containers>0, subnet~10.0, internal=true, attachable=true
+//   - used=false, anonymous=true
+//   - label.com.example.team=backend, label.version>=2, label.traefik.enable
+//   - cpu>50, mem>80, memory>512MB, pids>100
+//   - netio>1GB, netrx>100MB, nettx>100MB, blockio>1GB, blockread>1GB, blockwrite>1GB
+//     (containers without stats never match usage criteria)
+//   - port=8080, port=443/tcp, port~80
+//
+// Input starting with FuzzyPrefix is a fuzzy search instead: the rest is a
+// list of space-separated patterns, each matched fzf-style as a subsequence
+// of any column, and rows are ranked by how well they match.
+//
+// Syntax errors are returned as *SyntaxError with the column of the problem.
+// ParseFilter accepts the fields of every view; use ParseFilterFor to
+// restrict them to one.
 func ParseFilter(input string) (*Filter, error) {
-	input = strings.TrimSpace(input)
-	if input == "" {
-		return New(), nil
-	}
-
-	f := New()
-
-	// Check if this looks like an advanced filter (contains operators)
-	hasOperators := strings.ContainsAny(input, "=><~")
+	return ParseFilterFor(input, "")
+}
 
-	if !hasOperators {
-		// Simple search mode - just store the search term
-		f.SearchTerm = strings.ToLower(input)
+// ParseFilterFor parses a filter for one view, rejecting fields the view
+// does not have, operators that do not apply to a field and unknown values
+// of enumerated fields such as state, with suggestions for likely typos.
+func ParseFilterFor(input string, resource Resource) (*Filter, error) {
+	if pattern, ok := strings.CutPrefix(strings.TrimSpace(input), FuzzyPrefix); ok {
+		f := New()
+		f.Fuzzy = strings.ToLower(strings.TrimSpace(pattern))
 		return f, nil
 	}
 
-	// Advanced filter mode - parse criteria
-	parts := strings.Split(input, ",")
-	for _, part := range parts {
-		part = strings.TrimSpace(part)
-		if part == "" {
-			continue
-		}
+	expr, err := parseExpr(input, resource)
+	if err != nil {
+		return nil, err
+	}
 
-		criterion, err := parseCriterion(part)
-		if err != nil {
-			return nil, fmt.Errorf("parse criterion '%s': %w", part, err)
+	f := New()
+	f.Expr = expr
+	switch n := expr.(type) {
+	case *SearchNode:
+		f.SearchTerm = n.Term
+	case *CriterionNode:
+		f.Criteria = append(f.Criteria, n.Criterion)
+	case *AndNode:
+		var criteria []Criterion
+		for _, child := range n.Children {
+			cn, ok := child.(*CriterionNode)
+			if !ok {
+				criteria = nil
+				break
+			}
+			criteria = append(criteria, cn.Criterion)
 		}
-		f.Criteria = append(f.Criteria, criterion)
+		f.Criteria = append(f.Criteria, criteria...)
 	}
-
 	return f, nil
 }
 
-func parseCriterion(input string) (Criterion, error) {
+// newCriterion builds a criterion from its parts, parsing the value
+// according to the field type.
+func newCriterion(field string, op ComparisonOp, value string) (Criterion, error) {
 	var c Criterion
 
-	// Try to match operators in order of length (longest first to avoid prefix issues)
-	operators := []ComparisonOp{OpGreaterEqual, OpLessEqual, OpNotEqual, OpNotContains, OpRegex, OpEqual, OpGreater, OpLess, OpContains}
+	// Labels and inspect values may legitimately be empty, so label.<key>=
+	// and .Config.User= are allowed.
+	isLabel := strings.HasPrefix(field, string(FilterLabel)+".") || strings.HasPrefix(field, ".")
+	if value == "" && !(isLabel && (op == OpEqual || op == OpNotEqual)) {
+		return c, fmt.Errorf("missing value")
+	}
 
-	var op ComparisonOp
-	var splitIdx int
-	var opLen int
+	c.Type = FilterType(field)
+	c.Op = op
+	c.Value = value
 
-	for _, testOp := range operators {
-		idx := strings.Index(input, string(testOp))
-		if idx > 0 {
-			op = testOp
-			splitIdx = idx
-			opLen = len(string(testOp))
-			break
+	if key, ok := strings.CutPrefix(field, string(FilterLabel)+"."); ok {
+		if key == "" {
+			return c, fmt.Errorf("missing label key")
 		}
+		c.Type = FilterLabel
+		c.Key = key
 	}
-
-	if op == "" {
-		return c, fmt.Errorf("no valid operator found in: %s", input)
-	}
-
-	filterType := strings.TrimSpace(input[:splitIdx])
-	value := strings.TrimSpace(input[splitIdx+opLen:])
-
-	if filterType == "" || value == "" {
-		return c, fmt.Errorf("invalid filter format: %s", input)
+	if strings.HasPrefix(field, ".") {
+		path, err := parseInspectPath(field)
+		if err != nil {
+			return c, err
+		}
+		c.Type = FilterInspect
+		c.Key = field
+		c.path = path
 	}
 
-	c.Type = FilterType(filterType)
-	c.Op = op
-	c.Value = value
-
 	// Parse special values
 	switch c.Type {
 	case FilterAge:
-		dur, err := parseDuration(value)
-		if err != nil {
+		if err := parseAgeValue(&c, value); err != nil {
 			return c, fmt.Errorf("parse age duration: %w", err)
 		}
-		c.Duration = dur
-	case FilterSize:
+	case FilterCreated, FilterStarted, FilterFinished:
+		if err := parseTimeValue(&c, value, time.Now()); err != nil {
+			return c, fmt.Errorf("parse %s time: %w", c.Type, err)
+		}
+	case FilterSize, FilterMemory, FilterNetRx, FilterNetTx, FilterNetIO, FilterBlockRead, FilterBlockWrite, FilterBlockIO:
 		bytes, err := parseBytes(value)
 		if err != nil {
-			return c, fmt.Errorf("parse size: %w", err)
+			return c, fmt.Errorf("parse %s: %w", c.Type, err)
 		}
 		c.Bytes = bytes
+	case FilterCPU, FilterMem:
+		num, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil {
+			return c, fmt.Errorf("parse %s percent: %w", c.Type, err)
+		}
+		c.Number = num
+	case FilterPort:
+		if isOrdering(op) {
+			num, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return c, fmt.Errorf("parse port: %w", err)
+			}
+			c.Number = num
+		}
+	case FilterExitCode, FilterRestarts, FilterContainers, FilterPIDs:
+		num, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return c, fmt.Errorf("parse %s: %w", c.Type, err)
+		}
+		c.Number = num
+	case FilterOOM, FilterCrashLoop, FilterOutdated, FilterInternal, FilterAttachable, FilterUsed, FilterAnonymous, FilterDangling:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return c, fmt.Errorf("parse %s: %w", c.Type, err)
+		}
+		c.Bool = b
 	}
 
 	// Compile regex for regex operators
@@ -253,103 +384,252 @@ func parseBytes(s string) (int64, error) {
 	return val, nil
 }
 
-// MatchContainer checks if a container matches all filter criteria.
-func (f *Filter) MatchContainer(c docker.ContainerInfo) bool {
-	// Simple search mode - match across all fields
+// match evaluates the filter with the given leaf matchers. Filters built
+// without an expression fall back to the search term or the AND of Criteria.
+func (f *Filter) match(search func(term string) bool, criterion func(c Criterion) bool) bool {
+	if f.Fuzzy != "" {
+		return true
+	}
+	if f.Expr != nil {
+		return f.Expr.eval(matcher{search: search, criterion: criterion})
+	}
 	if f.SearchTerm != "" {
-		searchLower := f.SearchTerm
-		return strings.Contains(strings.ToLower(c.Name), searchLower) ||
-			strings.Contains(strings.ToLower(c.Image), searchLower) ||
-			strings.Contains(strings.ToLower(c.Status), searchLower) ||
-			strings.Contains(strings.ToLower(c.State), searchLower) ||
-			strings.Contains(strings.ToLower(c.ID), searchLower)
+		return search(f.SearchTerm)
 	}
-
-	// Advanced filter mode - check criteria
-	for _, criterion := range f.Criteria {
-		if !matchContainerCriterion(c, criterion) {
+	for _, c := range f.Criteria {
+		if !criterion(c) {
 			return false
 		}
 	}
 	return true
 }
 
+// MatchContainer checks if a container matches the filter.
+func (f *Filter) MatchContainer(c docker.ContainerInfo) bool {
+	if f.Fuzzy != "" {
+		_, ok := f.FuzzyContainer(c)
+		return ok
+	}
+	return f.matchRow(ResourceContainers, c.ID, func(term string) bool {
+		return matchContainerSearch(c, term)
+	}, func(criterion Criterion) bool {
+		return matchContainerCriterion(c, criterion)
+	})
+}
+
+func matchContainerSearch(c docker.ContainerInfo, term string) bool {
+	return strings.Contains(strings.ToLower(c.Name), term) ||
+		strings.Contains(strings.ToLower(c.Image), term) ||
+		strings.Contains(strings.ToLower(c.Status), term) ||
+		strings.Contains(strings.ToLower(c.State), term) ||
+		strings.Contains(strings.ToLower(c.ID), term)
+}
+
 func matchContainerCriterion(c docker.ContainerInfo, criterion Criterion) bool {
 	switch criterion.Type {
 	case FilterAge:
 		age := time.Since(c.Created)
-		return compareNumeric(float64(age), criterion.Op, float64(criterion.Duration))
+		return compareDuration(age, criterion)
+	case FilterCreated:
+		return matchTime(c.Created, criterion)
+	case FilterStarted:
+		return matchTime(c.StartedAt, criterion)
+	case FilterFinished:
+		return matchTime(c.FinishedAt, criterion)
 	case FilterStatus:
 		return compareString(c.Status, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterState:
 		return compareString(c.State, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterName:
 		return compareString(c.Name, criterion.Op, criterion.Value, criterion.Regex)
+	case FilterExitCode:
+		return compareNumeric(float64(c.ExitCode), criterion.Op, criterion.Number)
+	case FilterRestarts:
+		return compareNumeric(float64(c.RestartCount), criterion.Op, criterion.Number)
+	case FilterOOM:
+		return compareBool(c.OOMKilled, criterion.Op, criterion.Bool)
+	case FilterCrashLoop:
+		return compareBool(c.CrashLooping, criterion.Op, criterion.Bool)
+	case FilterOutdated:
+		return compareBool(c.Outdated, criterion.Op, criterion.Bool)
+	case FilterLabel:
+		return matchLabel(c.Labels, criterion)
+	case FilterCPU, FilterMem, FilterMemory, FilterNetRx, FilterNetTx, FilterNetIO,
+		FilterBlockRead, FilterBlockWrite, FilterBlockIO, FilterPIDs:
+		return matchUsage(c.Usage, criterion)
+	case FilterPort:
+		return matchPorts(c.PortMappings, criterion)
+	case FilterImage:
+		return matchNames([]string{c.Image}, criterion)
+	case FilterNetwork:
+		return matchNames(c.Networks, criterion)
+	case FilterVolume:
+		return matchNames(c.Volumes, criterion)
 	default:
-		return true
+		// A field this resource does not have matches nothing.
+		return false
 	}
 }
 
-// MatchImage checks if an image matches all filter criteria.
-func (f *Filter) MatchImage(img docker.ImageInfo) bool {
-	// Simple search mode - match across all fields
-	if f.SearchTerm != "" {
-		searchLower := f.SearchTerm
-		return strings.Contains(strings.ToLower(img.Tag), searchLower) ||
-			strings.Contains(strings.ToLower(img.ID), searchLower) ||
-			strings.Contains(strings.ToLower(img.Size), searchLower)
+// matchNames checks a list of network or volume names. = compares exactly,
+// as Docker names are case-sensitive. Positive operators match when any
+// name does; != and !~ match when no name does.
+func matchNames(names []string, criterion Criterion) bool {
+	switch criterion.Op {
+	case OpNotEqual:
+		inverse := criterion
+		inverse.Op = OpEqual
+		return !matchNames(names, inverse)
+	case OpNotContains:
+		inverse := criterion
+		inverse.Op = OpContains
+		return !matchNames(names, inverse)
+	}
+	for _, name := range names {
+		if criterion.Op == OpEqual && name == criterion.Value {
+			return true
+		}
+		if criterion.Op != OpEqual && compareString(name, criterion.Op, criterion.Value, criterion.Regex) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Advanced filter mode - check criteria
-	for _, criterion := range f.Criteria {
-		if !matchImageCriterion(img, criterion) {
-			return false
+// matchUsage compares a resource usage figure. Containers without stats
+// (stopped ones, or when the stats call timed out) never match, whatever
+// the operator; wrap the criterion in not to include them.
+func matchUsage(usage *docker.ResourceUsage, criterion Criterion) bool {
+	if usage == nil {
+		return false
+	}
+	switch criterion.Type {
+	case FilterCPU:
+		return compareNumeric(usage.CPUPercent, criterion.Op, criterion.Number)
+	case FilterMem:
+		return compareNumeric(usage.MemoryPercent, criterion.Op, criterion.Number)
+	case FilterPIDs:
+		return compareNumeric(float64(usage.PIDs), criterion.Op, criterion.Number)
+	}
+
+	var actual uint64
+	switch criterion.Type {
+	case FilterMemory:
+		actual = usage.MemoryBytes
+	case FilterNetRx:
+		actual = usage.NetRxBytes
+	case FilterNetTx:
+		actual = usage.NetTxBytes
+	case FilterNetIO:
+		actual = usage.NetRxBytes + usage.NetTxBytes
+	case FilterBlockRead:
+		actual = usage.BlockRead
+	case FilterBlockWrite:
+		actual = usage.BlockWrite
+	case FilterBlockIO:
+		actual = usage.BlockRead + usage.BlockWrite
+	}
+	return compareNumeric(float64(actual), criterion.Op, float64(criterion.Bytes))
+}
+
+// matchPorts checks the container's ports. Each port is known by its private
+// number, its published number and "<private>/<proto>". Positive operators
+// match when any port does; != and !~ match when no port does.
+func matchPorts(ports []docker.PortMapping, criterion Criterion) bool {
+	switch criterion.Op {
+	case OpNotEqual:
+		inverse := criterion
+		inverse.Op = OpEqual
+		return !matchPorts(ports, inverse)
+	case OpNotContains:
+		inverse := criterion
+		inverse.Op = OpContains
+		return !matchPorts(ports, inverse)
+	}
+
+	for _, p := range ports {
+		if isOrdering(criterion.Op) {
+			if compareNumeric(float64(p.PrivatePort), criterion.Op, criterion.Number) ||
+				(p.PublicPort > 0 && compareNumeric(float64(p.PublicPort), criterion.Op, criterion.Number)) {
+				return true
+			}
+			continue
+		}
+		names := []string{strconv.Itoa(int(p.PrivatePort)), fmt.Sprintf("%d/%s", p.PrivatePort, p.Type)}
+		if p.PublicPort > 0 {
+			names = append(names, strconv.Itoa(int(p.PublicPort)), fmt.Sprintf("%d/%s", p.PublicPort, p.Type))
+		}
+		for _, name := range names {
+			if compareString(name, criterion.Op, criterion.Value, criterion.Regex) {
+				return true
+			}
 		}
 	}
-	return true
+	return false
+}
+
+func isOrdering(op ComparisonOp) bool {
+	return op == OpGreater || op == OpLess || op == OpGreaterEqual || op == OpLessEqual
+}
+
+// MatchImage checks if an image matches the filter.
+func (f *Filter) MatchImage(img docker.ImageInfo) bool {
+	if f.Fuzzy != "" {
+		_, ok := f.FuzzyImage(img)
+		return ok
+	}
+	return f.matchRow(ResourceImages, img.ID, func(term string) bool {
+		return matchImageSearch(img, term)
+	}, func(criterion Criterion) bool {
+		return matchImageCriterion(img, criterion)
+	})
+}
+
+func matchImageSearch(img docker.ImageInfo, term string) bool {
+	return strings.Contains(strings.ToLower(img.Tag), term) ||
+		strings.Contains(strings.ToLower(img.ID), term) ||
+		strings.Contains(strings.ToLower(img.Size), term)
 }
 
 func matchImageCriterion(img docker.ImageInfo, criterion Criterion) bool {
 	switch criterion.Type {
 	case FilterAge:
 		age := time.Since(img.Created)
-		return compareNumeric(float64(age), criterion.Op, float64(criterion.Duration))
+		return compareDuration(age, criterion)
+	case FilterCreated:
+		return matchTime(img.Created, criterion)
 	case FilterName, FilterTag:
 		return compareString(img.Tag, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterSize:
-		// Parse size from string (e.g., "100.50 MB")
-		sizeStr := strings.Fields(img.Size)
-		if len(sizeStr) >= 1 {
-			val, err := strconv.ParseFloat(sizeStr[0], 64)
-			if err == nil {
-				bytes := int64(val * 1024 * 1024) // Assuming MB
-				return compareNumeric(float64(bytes), criterion.Op, float64(criterion.Bytes))
-			}
-		}
-		return true
+		return compareNumeric(float64(img.SizeBytes), criterion.Op, float64(criterion.Bytes))
+	case FilterDangling:
+		return compareBool(img.Dangling, criterion.Op, criterion.Bool)
+	case FilterLabel:
+		return matchLabel(img.Labels, criterion)
 	default:
-		return true
+		// A field this resource does not have matches nothing.
+		return false
 	}
 }
 
-// MatchNetwork checks if a network matches all filter criteria.
+// MatchNetwork checks if a network matches the filter.
 func (f *Filter) MatchNetwork(net docker.NetworkInfo) bool {
-	// Simple search mode - match across all fields
-	if f.SearchTerm != "" {
-		searchLower := f.SearchTerm
-		return strings.Contains(strings.ToLower(net.Name), searchLower) ||
-			strings.Contains(strings.ToLower(net.ID), searchLower) ||
-			strings.Contains(strings.ToLower(net.Driver), searchLower) ||
-			strings.Contains(strings.ToLower(net.Scope), searchLower)
-	}
+	if f.Fuzzy != "" {
+		_, ok := f.FuzzyNetwork(net)
+		return ok
+	}
+	return f.matchRow(ResourceNetworks, net.ID, func(term string) bool {
+		return matchNetworkSearch(net, term)
+	}, func(criterion Criterion) bool {
+		return matchNetworkCriterion(net, criterion)
+	})
+}
 
-	// Advanced filter mode - check criteria
-	for _, criterion := range f.Criteria {
-		if !matchNetworkCriterion(net, criterion) {
-			return false
-		}
-	}
-	return true
+func matchNetworkSearch(net docker.NetworkInfo, term string) bool {
+	return strings.Contains(strings.ToLower(net.Name), term) ||
+		strings.Contains(strings.ToLower(net.ID), term) ||
+		strings.Contains(strings.ToLower(net.Driver), term) ||
+		strings.Contains(strings.ToLower(net.Scope), term)
 }
 
 func matchNetworkCriterion(net docker.NetworkInfo, criterion Criterion) bool {
@@ -357,37 +637,50 @@ func matchNetworkCriterion(net docker.NetworkInfo, criterion Criterion) bool {
 	case FilterAge:
 		if !net.Created.IsZero() {
 			age := time.Since(net.Created)
-			return compareNumeric(float64(age), criterion.Op, float64(criterion.Duration))
+			return compareDuration(age, criterion)
 		}
 		return true
+	case FilterCreated:
+		return matchTime(net.Created, criterion)
 	case FilterName:
 		return compareString(net.Name, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterDriver:
 		return compareString(net.Driver, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterScope:
 		return compareString(net.Scope, criterion.Op, criterion.Value, criterion.Regex)
+	case FilterContainers:
+		return compareNumeric(float64(len(net.Members)), criterion.Op, criterion.Number)
+	case FilterSubnet:
+		return compareString(strings.Join(net.Subnets, ","), criterion.Op, criterion.Value, criterion.Regex)
+	case FilterInternal:
+		return compareBool(net.Internal, criterion.Op, criterion.Bool)
+	case FilterAttachable:
+		return compareBool(net.Attachable, criterion.Op, criterion.Bool)
+	case FilterLabel:
+		return matchLabel(net.Labels, criterion)
 	default:
-		return true
+		// A field this resource does not have matches nothing.
+		return false
 	}
 }
 
-// MatchVolume checks if a volume matches all filter criteria.
+// MatchVolume checks if a volume matches the filter.
 func (f *Filter) MatchVolume(vol docker.VolumeInfo) bool {
-	// Simple search mode - match across all fields
-	if f.SearchTerm != "" {
-		searchLower := f.SearchTerm
-		return strings.Contains(strings.ToLower(vol.Name), searchLower) ||
-			strings.Contains(strings.ToLower(vol.Driver), searchLower) ||
-			strings.Contains(strings.ToLower(vol.Mountpoint), searchLower)
-	}
+	if f.Fuzzy != "" {
+		_, ok := f.FuzzyVolume(vol)
+		return ok
+	}
+	return f.matchRow(ResourceVolumes, vol.Name, func(term string) bool {
+		return matchVolumeSearch(vol, term)
+	}, func(criterion Criterion) bool {
+		return matchVolumeCriterion(vol, criterion)
+	})
+}
 
-	// Advanced filter mode - check criteria
-	for _, criterion := range f.Criteria {
-		if !matchVolumeCriterion(vol, criterion) {
-			return false
-		}
-	}
-	return true
+func matchVolumeSearch(vol docker.VolumeInfo, term string) bool {
+	return strings.Contains(strings.ToLower(vol.Name), term) ||
+		strings.Contains(strings.ToLower(vol.Driver), term) ||
+		strings.Contains(strings.ToLower(vol.Mountpoint), term)
 }
 
 func matchVolumeCriterion(vol docker.VolumeInfo, criterion Criterion) bool {
@@ -395,18 +688,50 @@ func matchVolumeCriterion(vol docker.VolumeInfo, criterion Criterion) bool {
 	case FilterAge:
 		if !vol.Created.IsZero() {
 			age := time.Since(vol.Created)
-			return compareNumeric(float64(age), criterion.Op, float64(criterion.Duration))
+			return compareDuration(age, criterion)
 		}
 		return true
+	case FilterCreated:
+		return matchTime(vol.Created, criterion)
 	case FilterName:
 		return compareString(vol.Name, criterion.Op, criterion.Value, criterion.Regex)
 	case FilterDriver:
 		return compareString(vol.Driver, criterion.Op, criterion.Value, criterion.Regex)
+	case FilterUsed:
+		return compareBool(len(vol.UsedBy) > 0, criterion.Op, criterion.Bool)
+	case FilterAnonymous:
+		return compareBool(vol.Anonymous, criterion.Op, criterion.Bool)
+	case FilterLabel:
+		return matchLabel(vol.Labels, criterion)
 	default:
-		return true
+		// A field this resource does not have matches nothing.
+		return false
 	}
 }
 
+// matchLabel compares the value of the criterion's label key. A missing
+// label only satisfies negative comparisons. Ordering operators compare
+// numerically when both sides are numbers and lexically otherwise.
+func matchLabel(labels map[string]string, criterion Criterion) bool {
+	value, ok := labels[criterion.Key]
+	switch {
+	case criterion.Op == OpExists:
+		return ok
+	case !ok:
+		return criterion.Op == OpNotEqual || criterion.Op == OpNotContains
+	}
+
+	if isOrdering(criterion.Op) {
+		actual, errA := strconv.ParseFloat(value, 64)
+		expected, errE := strconv.ParseFloat(criterion.Value, 64)
+		if errA == nil && errE == nil {
+			return compareNumeric(actual, criterion.Op, expected)
+		}
+		return compareNumeric(float64(strings.Compare(value, criterion.Value)), criterion.Op, 0)
+	}
+	return compareString(value, criterion.Op, criterion.Value, criterion.Regex)
+}
+
 func compareString(actual string, op ComparisonOp, expected string, regex *regexp.Regexp) bool {
 	switch op {
 	case OpEqual:
@@ -443,8 +768,25 @@ func compareNumeric(actual float64, op ComparisonOp, expected float64) bool {
 	}
 }
 
+func compareBool(actual bool, op ComparisonOp, expected bool) bool {
+	switch op {
+	case OpEqual:
+		return actual == expected
+	case OpNotEqual:
+		return actual != expected
+	default:
+		return false
+	}
+}
+
 // String returns a human-readable representation of the filter.
 func (f *Filter) String() string {
+	if f.Fuzzy != "" {
+		return FuzzyPrefix + f.Fuzzy
+	}
+	if f.Expr != nil {
+		return f.Expr.String()
+	}
 	if f.SearchTerm != "" {
 		return f.SearchTerm
 	}
@@ -455,12 +797,12 @@ func (f *Filter) String() string {
 
 	parts := make([]string, len(f.Criteria))
 	for i, c := range f.Criteria {
-		parts[i] = fmt.Sprintf("%s%s%s", c.Type, c.Op, c.Value)
+		parts[i] = c.String()
 	}
 	return strings.Join(parts, ", ")
 }
 
-// IsEmpty returns true if the filter has no criteria and no search term.
+// IsEmpty returns true if the filter has no expression, criteria or search term.
 func (f *Filter) IsEmpty() bool {
-	return len(f.Criteria) == 0 && f.SearchTerm == ""
+	return f.Expr == nil && len(f.Criteria) == 0 && f.SearchTerm == "" && f.Fuzzy == ""
 }
@@ -26,6 +26,8 @@ func TestParseFilter(t *testing.T) {
 		{"size filter", "size>100MB", false, "", 1},
 		{"driver filter", "driver=bridge", false, "", 1},
 		{"invalid empty value", "age>", true, "", 0},
+		{"unknown field", "foo=bar", true, "", 0},
+		{"misspelt field", "nme=web", true, "", 0},
 	}
 
 	for _, tt := range tests {
@@ -112,6 +114,7 @@ func TestMatchContainer(t *testing.T) {
 	containers := []docker.ContainerInfo{
 		{
 			Name:    "redis-server",
+			Image:   "redis:7",
 			State:   "running",
 			Created: now.Add(-2 * time.Hour),
 		},
@@ -143,6 +146,8 @@ func TestMatchContainer(t *testing.T) {
 		{"age less no match", "age<1h", containers[0], false},
 		{"multiple criteria match", "state=running,age>1h", containers[0], true},
 		{"multiple criteria no match", "state=running,age>3h", containers[0], false},
+		{"image exact match", "image=redis:7", containers[0], true},
+		{"image is case-sensitive", "image=Redis:7", containers[0], false},
 	}
 
 	for _, tt := range tests {
@@ -164,14 +169,16 @@ func TestMatchImage(t *testing.T) {
 
 	images := []docker.ImageInfo{
 		{
-			Tag:     "ubuntu:latest",
-			Size:    "100.50 MB",
-			Created: now.Add(-24 * time.Hour),
+			Tag:       "ubuntu:latest",
+			Size:      "100.50 MB",
+			SizeBytes: 100_500_000,
+			Created:   now.Add(-24 * time.Hour),
 		},
 		{
-			Tag:     "nginx:alpine",
-			Size:    "50.25 MB",
-			Created: now.Add(-5 * time.Hour),
+			Tag:       "nginx:alpine",
+			Size:      "50.25 MB",
+			SizeBytes: 50_250_000,
+			Created:   now.Add(-5 * time.Hour),
 		},
 	}
 
@@ -185,6 +192,10 @@ func TestMatchImage(t *testing.T) {
 		{"tag contains no match", "tag~redis", images[0], false},
 		{"age greater match", "age>12h", images[0], true},
 		{"age less match", "age<12h", images[1], true},
+		{"size greater match", "size>60MB", images[0], true},
+		{"size greater no match", "size>60MB", images[1], false},
+		{"size less match", "size<60MB", images[1], true},
+		{"size unparsable display string", "size>1B", docker.ImageInfo{Size: "N/A"}, false},
 	}
 
 	for _, tt := range tests {
@@ -310,3 +321,353 @@ func TestSimpleSearchImage(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchContainerLifecycle(t *testing.T) {
+	crashed := docker.ContainerInfo{
+		Name:         "worker",
+		State:        "exited",
+		ExitCode:     137,
+		OOMKilled:    true,
+		RestartCount: 5,
+		CrashLooping: true,
+	}
+	healthy := docker.ContainerInfo{
+		Name:  "api",
+		State: "running",
+	}
+
+	tests := []struct {
+		name      string
+		filter    string
+		container docker.ContainerInfo
+		want      bool
+	}{
+		{"nonzero exit match", "exitcode!=0", crashed, true},
+		{"nonzero exit no match", "exitcode!=0", healthy, false},
+		{"oom match", "oom=true", crashed, true},
+		{"oom no match", "oom=true", healthy, false},
+		{"restarts match", "restarts>3", crashed, true},
+		{"restarts no match", "restarts>3", healthy, false},
+		{"crashloop match", "crashloop=true", crashed, true},
+		{"crashloop negated", "crashloop!=true", healthy, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchContainer(tt.container); got != tt.want {
+				t.Errorf("MatchContainer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterLifecycleErrors(t *testing.T) {
+	for _, input := range []string{"exitcode=abc", "oom=maybe", "restarts>many"} {
+		if _, err := ParseFilter(input); err == nil {
+			t.Errorf("ParseFilter(%q) expected error", input)
+		}
+	}
+}
+
+func TestMatchFieldOfAnotherView(t *testing.T) {
+	f, err := ParseFilter("dangling=true")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	if f.MatchContainer(docker.ContainerInfo{Name: "web"}) {
+		t.Error("image field matched a container")
+	}
+	if f.MatchNetwork(docker.NetworkInfo{Name: "bridge"}) {
+		t.Error("image field matched a network")
+	}
+	if f.MatchVolume(docker.VolumeInfo{Name: "data"}) {
+		t.Error("image field matched a volume")
+	}
+
+	f, err = ParseFilter("status=running")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	if f.MatchImage(docker.ImageInfo{Tag: "redis:7"}) {
+		t.Error("container field matched an image")
+	}
+}
+
+func TestMatchContainerOutdated(t *testing.T) {
+	f, err := ParseFilter("outdated=true")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	if !f.MatchContainer(docker.ContainerInfo{Outdated: true}) {
+		t.Error("expected outdated container to match")
+	}
+	if f.MatchContainer(docker.ContainerInfo{}) {
+		t.Error("expected current container not to match")
+	}
+}
+
+func TestMatchNetworkTopology(t *testing.T) {
+	used := docker.NetworkInfo{
+		Name:     "frontend",
+		Subnets:  []string{"172.18.0.0/16"},
+		Internal: true,
+		Members:  []docker.NetworkMember{{Name: "web"}, {Name: "api"}},
+	}
+	unused := docker.NetworkInfo{Name: "scratch", Attachable: true}
+
+	tests := []struct {
+		name    string
+		filter  string
+		network docker.NetworkInfo
+		want    bool
+	}{
+		{"has containers", "containers>0", used, true},
+		{"unused network", "containers=0", unused, true},
+		{"unused excluded", "containers>0", unused, false},
+		{"subnet contains", "subnet~172.18", used, true},
+		{"subnet no match", "subnet~10.0", used, false},
+		{"internal", "internal=true", used, true},
+		{"attachable", "attachable=true", used, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchNetwork(tt.network); got != tt.want {
+				t.Errorf("MatchNetwork() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchVolumeUsage(t *testing.T) {
+	orphan := docker.VolumeInfo{Name: "3f1c9e", Anonymous: true}
+	inUse := docker.VolumeInfo{Name: "pgdata", UsedBy: []docker.VolumeUser{{Name: "db"}}}
+
+	tests := []struct {
+		name   string
+		filter string
+		volume docker.VolumeInfo
+		want   bool
+	}{
+		{"unused", "used=false", orphan, true},
+		{"used excluded", "used=false", inUse, false},
+		{"used", "used=true", inUse, true},
+		{"anonymous", "anonymous=true", orphan, true},
+		{"named excluded", "anonymous=true", inUse, false},
+		{"orphaned anonymous", "used=false, anonymous=true", orphan, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchVolume(tt.volume); got != tt.want {
+				t.Errorf("MatchVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchVolumeLabel(t *testing.T) {
+	vol := docker.VolumeInfo{Name: "pgdata", Labels: map[string]string{"com.example.team": "db", "backup": "daily"}}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{"dotted key", "label.com.example.team=db", true},
+		{"value mismatch", "label.backup=weekly", false},
+		{"case-insensitive value", "label.backup=Daily", true},
+		{"missing label", "label.owner=alice", false},
+		{"missing label negated", "label.owner!=alice", true},
+		{"combined", "label.backup=daily, name=pgdata", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchVolume(vol); got != tt.want {
+				t.Errorf("MatchVolume() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ParseFilter("label.=x"); err == nil {
+		t.Errorf("ParseFilter(label.=x) should fail")
+	}
+}
+
+func TestMatchLabelOperators(t *testing.T) {
+	labels := map[string]string{
+		"com.example.version": "10",
+		"com.example.team":    "payments-api",
+		"traefik.enable":      "true",
+		"empty":               "",
+	}
+	ctr := docker.ContainerInfo{Name: "web", Labels: labels}
+	img := docker.ImageInfo{Tag: "web:1", Labels: labels}
+	net := docker.NetworkInfo{Name: "web-front", Labels: labels}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{"exists", "label.traefik.enable", true},
+		{"missing", "label.owner", false},
+		{"exists combined", "label.traefik.enable, name~we", true},
+		{"numeric greater", "label.com.example.version>9", true},
+		{"numeric not lexical", "label.com.example.version>=9", true},
+		{"numeric less", "label.com.example.version<10", false},
+		{"numeric less equal", "label.com.example.version<=10", true},
+		{"lexical order", "label.com.example.team>orders", true},
+		{"contains", "label.com.example.team~payments", true},
+		{"not contains", "label.com.example.team!~orders", true},
+		{"regex", "label.com.example.team=~^pay.*api$", true},
+		{"not equal", "label.com.example.team!=orders", true},
+		{"empty value", "label.empty=", true},
+		{"missing with ordering", "label.owner>1", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchContainer(ctr); got != tt.want {
+				t.Errorf("MatchContainer() = %v, want %v", got, tt.want)
+			}
+			if got := f.MatchImage(img); got != tt.want {
+				t.Errorf("MatchImage() = %v, want %v", got, tt.want)
+			}
+			if got := f.MatchNetwork(net); got != tt.want {
+				t.Errorf("MatchNetwork() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseFilterBareLabelIsNotSearch(t *testing.T) {
+	f, err := ParseFilter("label.traefik.enable")
+	if err != nil {
+		t.Fatalf("ParseFilter() error = %v", err)
+	}
+	if f.SearchTerm != "" || len(f.Criteria) != 1 || f.Criteria[0].Op != OpExists || f.Criteria[0].Key != "traefik.enable" {
+		t.Errorf("ParseFilter() = %+v, want one existence criterion", f)
+	}
+
+	f, err = ParseFilter("labels")
+	if err != nil || f.SearchTerm != "labels" {
+		t.Errorf("ParseFilter(labels) = %+v, %v, want a plain search", f, err)
+	}
+}
+
+func TestMatchContainerUsage(t *testing.T) {
+	busy := docker.ContainerInfo{
+		Name:  "busy",
+		State: "running",
+		Usage: &docker.ResourceUsage{
+			CPUPercent:    75.5,
+			MemoryPercent: 40,
+			MemoryBytes:   600 * 1024 * 1024,
+			NetRxBytes:    700 * 1024 * 1024,
+			NetTxBytes:    400 * 1024 * 1024,
+			BlockRead:     10 * 1024 * 1024,
+			BlockWrite:    20 * 1024 * 1024,
+			PIDs:          120,
+		},
+	}
+	stopped := docker.ContainerInfo{Name: "stopped", State: "exited"}
+
+	tests := []struct {
+		name      string
+		filter    string
+		container docker.ContainerInfo
+		want      bool
+	}{
+		{"cpu above", "cpu>50", busy, true},
+		{"cpu percent sign", "cpu<=50%", busy, false},
+		{"mem percent", "mem>=40", busy, true},
+		{"memory bytes", "memory>512MB", busy, true},
+		{"netio sums both directions", "netio>1GB", busy, true},
+		{"netrx", "netrx>1GB", busy, false},
+		{"blockio", "blockio=30MB", busy, true},
+		{"blockwrite", "blockwrite<15MB", busy, false},
+		{"pids", "pids>100", busy, true},
+		{"stopped never matches", "cpu<50", stopped, false},
+		{"stopped never matches negative", "cpu!=50", stopped, false},
+		{"negated includes stopped", "not cpu>50", stopped, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchContainer(tt.container); got != tt.want {
+				t.Errorf("MatchContainer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchContainerPorts(t *testing.T) {
+	web := docker.ContainerInfo{
+		Name: "web",
+		PortMappings: []docker.PortMapping{
+			{PrivatePort: 80, PublicPort: 8080, Type: "tcp"},
+			{PrivatePort: 443, Type: "tcp"},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		filter string
+		want   bool
+	}{
+		{"private port", "port=80", true},
+		{"published port", "port=8080", true},
+		{"with protocol", "port=443/tcp", true},
+		{"wrong protocol", "port=443/udp", false},
+		{"contains", "port~80", true},
+		{"not equal means no port", "port!=443", false},
+		{"not equal absent", "port!=22", true},
+		{"not contains", "port!~22", true},
+		{"ordering", "port>8000", true},
+		{"ordering none", "port<80", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			if got := f.MatchContainer(web); got != tt.want {
+				t.Errorf("MatchContainer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"port>http", "cpu>lots", "bogus=1"} {
+		if _, err := ParseFilter(input); err == nil {
+			t.Errorf("ParseFilter(%q) should fail", input)
+		}
+	}
+}
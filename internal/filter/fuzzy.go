@@ -0,0 +1,254 @@
+package filter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"dock-it/internal/docker"
+)
+
+// Fuzzy scoring follows fzf: every matched character scores, gaps cost a
+// start and an extension penalty, and characters at word boundaries or in
+// a contiguous run earn bonuses so "dkr" ranks "docker-registry" above
+// "dark-mirror".
+const (
+	scoreMatch        = 16
+	scoreGapStart     = -3
+	scoreGapExtension = -1
+
+	bonusBoundaryWhite     = scoreMatch/2 + 2 // start of text or after whitespace
+	bonusBoundaryDelimiter = scoreMatch/2 + 1 // after / : ; , |
+	bonusBoundary          = scoreMatch / 2   // after other non-word characters
+	bonusCamel             = bonusBoundary + scoreGapExtension
+	bonusConsecutive       = -(scoreGapStart + scoreGapExtension)
+	bonusFirstCharFactor   = 2
+)
+
+// fuzzyMatch scores pattern as a case-insensitive subsequence of text. It
+// returns the best score, the byte offsets of the matched runes in text, and
+// whether pattern matched at all.
+func fuzzyMatch(pattern, text string) (int, []int, bool) {
+	pat := []rune(strings.ToLower(pattern))
+	if len(pat) == 0 {
+		return 0, nil, true
+	}
+
+	runes := make([]rune, 0, len(text))
+	offsets := make([]int, 0, len(text))
+	for i, r := range text {
+		runes = append(runes, r)
+		offsets = append(offsets, i)
+	}
+	lower := make([]rune, len(runes))
+	for i, r := range runes {
+		lower[i] = unicode.ToLower(r)
+	}
+	if !isSubsequence(pat, lower) {
+		return 0, nil, false
+	}
+
+	n, m := len(runes), len(pat)
+	bonus := make([]int, n)
+	for j := range runes {
+		bonus[j] = boundaryBonus(runes, j)
+	}
+
+	// score[i][j] is the best score of pat[:i+1] with pat[i] matched at
+	// text[j]; from[i][j] is where pat[i-1] was matched on that path.
+	const none = -1 << 30
+	score := make([][]int, m)
+	from := make([][]int, m)
+	for i := range score {
+		score[i] = make([]int, n)
+		from[i] = make([]int, n)
+		for j := range score[i] {
+			score[i][j] = none
+		}
+	}
+
+	for j := 0; j < n; j++ {
+		if lower[j] == pat[0] {
+			score[0][j] = scoreMatch + bonus[j]*bonusFirstCharFactor
+		}
+	}
+	for i := 1; i < m; i++ {
+		// gap is the best score[i-1][k] for k <= j-2 with the penalty for
+		// skipping text[k+1:j].
+		gap, gapFrom := none, -1
+		for j := 1; j < n; j++ {
+			if j >= 2 {
+				gap += scoreGapExtension
+				if s := score[i-1][j-2]; s != none && s+scoreGapStart > gap {
+					gap, gapFrom = s+scoreGapStart, j-2
+				}
+			}
+			if lower[j] != pat[i] {
+				continue
+			}
+			best, prev := none, -1
+			if s := score[i-1][j-1]; s != none {
+				best, prev = s+max(bonus[j], bonusConsecutive), j-1
+			}
+			if gapFrom >= 0 && gap+bonus[j] > best {
+				best, prev = gap+bonus[j], gapFrom
+			}
+			if prev >= 0 {
+				score[i][j] = scoreMatch + best
+				from[i][j] = prev
+			}
+		}
+	}
+
+	end := -1
+	for j := 0; j < n; j++ {
+		if score[m-1][j] != none && (end < 0 || score[m-1][j] > score[m-1][end]) {
+			end = j
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	positions := make([]int, m)
+	for i, j := m-1, end; i >= 0; i-- {
+		positions[i] = offsets[j]
+		j = from[i][j]
+	}
+	return score[m-1][end], positions, true
+}
+
+func isSubsequence(pat, text []rune) bool {
+	i := 0
+	for _, r := range text {
+		if i < len(pat) && r == pat[i] {
+			i++
+		}
+	}
+	return i == len(pat)
+}
+
+func boundaryBonus(runes []rune, j int) int {
+	if j == 0 {
+		return bonusBoundaryWhite
+	}
+	prev, cur := runes[j-1], runes[j]
+	switch {
+	case unicode.IsSpace(prev):
+		return bonusBoundaryWhite
+	case strings.ContainsRune("/:;,|", prev):
+		return bonusBoundaryDelimiter
+	case !isWordRune(prev) && isWordRune(cur):
+		return bonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return bonusCamel
+	case unicode.IsLetter(prev) && unicode.IsDigit(cur):
+		return bonusCamel
+	}
+	return 0
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// FuzzyMatch is how a row matched a fuzzy filter: the summed score of its
+// terms and, for each column a term scored in, the byte ranges it matched.
+type FuzzyMatch struct {
+	Score  int
+	ranges map[string][][2]int // by column text
+}
+
+// Ranges returns the sorted, non-overlapping byte ranges to highlight in a
+// column showing text. Columns no term scored in have none.
+func (m FuzzyMatch) Ranges(text string) [][2]int {
+	return m.ranges[text]
+}
+
+// fuzzyColumns matches every space-separated term of pattern against the
+// best of columns and sums the scores. ok is false when some term matches
+// none.
+func fuzzyColumns(pattern string, columns ...string) (FuzzyMatch, bool) {
+	m := FuzzyMatch{ranges: make(map[string][][2]int)}
+	for _, term := range strings.Fields(pattern) {
+		best, column, found := 0, "", false
+		var positions []int
+		for _, text := range columns {
+			if s, p, ok := fuzzyMatch(term, text); ok && (!found || s > best) {
+				best, column, positions, found = s, text, p, true
+			}
+		}
+		if !found {
+			return FuzzyMatch{}, false
+		}
+		m.Score += best
+		for _, p := range positions {
+			_, size := utf8.DecodeRuneInString(column[p:])
+			m.ranges[column] = append(m.ranges[column], [2]int{p, p + size})
+		}
+	}
+	for column, ranges := range m.ranges {
+		m.ranges[column] = mergeRanges(ranges)
+	}
+	return m, true
+}
+
+// IsFuzzy reports whether the filter is a fuzzy search.
+func (f *Filter) IsFuzzy() bool {
+	return f.Fuzzy != ""
+}
+
+// FuzzyContainer matches a container against a fuzzy filter. ok is false
+// when it does not match or the filter is not fuzzy.
+func (f *Filter) FuzzyContainer(c docker.ContainerInfo) (FuzzyMatch, bool) {
+	if f.Fuzzy == "" {
+		return FuzzyMatch{}, false
+	}
+	return fuzzyColumns(f.Fuzzy, containerColumns(c)...)
+}
+
+// FuzzyImage matches an image against a fuzzy filter.
+func (f *Filter) FuzzyImage(img docker.ImageInfo) (FuzzyMatch, bool) {
+	if f.Fuzzy == "" {
+		return FuzzyMatch{}, false
+	}
+	return fuzzyColumns(f.Fuzzy, imageColumns(img)...)
+}
+
+// FuzzyNetwork matches a network against a fuzzy filter.
+func (f *Filter) FuzzyNetwork(net docker.NetworkInfo) (FuzzyMatch, bool) {
+	if f.Fuzzy == "" {
+		return FuzzyMatch{}, false
+	}
+	return fuzzyColumns(f.Fuzzy, networkColumns(net)...)
+}
+
+// FuzzyVolume matches a volume against a fuzzy filter.
+func (f *Filter) FuzzyVolume(vol docker.VolumeInfo) (FuzzyMatch, bool) {
+	if f.Fuzzy == "" {
+		return FuzzyMatch{}, false
+	}
+	return fuzzyColumns(f.Fuzzy, volumeColumns(vol)...)
+}
+
+// The columns fuzzy search looks at, per view, as the table shows them.
+
+func containerColumns(c docker.ContainerInfo) []string {
+	id := c.ID
+	if len(id) > 12 {
+		id = id[:12]
+	}
+	return []string{c.Name, id, c.Image, c.State, c.Status, c.Ports}
+}
+
+func imageColumns(img docker.ImageInfo) []string {
+	return []string{img.Tag, img.ID, img.Size}
+}
+
+func networkColumns(net docker.NetworkInfo) []string {
+	return []string{net.Name, net.ID, net.Driver, net.Scope, strings.Join(net.Subnets, ", ")}
+}
+
+func volumeColumns(vol docker.VolumeInfo) []string {
+	return []string{vol.Name, vol.Driver, vol.Mountpoint}
+}
@@ -0,0 +1,111 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+
+	"dock-it/internal/docker"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		pattern   string
+		text      string
+		ok        bool
+		positions []int
+	}{
+		{"dkr", "docker-registry", true, []int{0, 3, 7}},
+		{"web", "my-web-app", true, []int{3, 4, 5}},
+		{"WEB", "my-web-app", true, []int{3, 4, 5}},
+		{"pg", "postgres", true, []int{0, 4}},
+		{"xyz", "postgres", false, nil},
+		{"sp", "ps", false, nil},
+		{"", "anything", true, nil},
+	}
+	for _, tt := range tests {
+		_, positions, ok := fuzzyMatch(tt.pattern, tt.text)
+		if ok != tt.ok {
+			t.Errorf("fuzzyMatch(%q, %q) ok = %v, want %v", tt.pattern, tt.text, ok, tt.ok)
+			continue
+		}
+		if !reflect.DeepEqual(positions, tt.positions) {
+			t.Errorf("fuzzyMatch(%q, %q) positions = %v, want %v", tt.pattern, tt.text, positions, tt.positions)
+		}
+	}
+}
+
+func TestFuzzyRanking(t *testing.T) {
+	tests := []struct {
+		pattern       string
+		better, worse string
+	}{
+		{"dkr", "docker-registry", "dark-mirror"},
+		{"web", "web-frontend", "my-webapp"},
+		{"api", "api-gateway", "rabbit-mq-pipeline"},
+		{"db", "my-db", "dashboard"},
+	}
+	for _, tt := range tests {
+		better, ok1 := fuzzyColumns(tt.pattern, tt.better)
+		worse, ok2 := fuzzyColumns(tt.pattern, tt.worse)
+		if !ok1 || !ok2 {
+			t.Fatalf("fuzzyColumns(%q) did not match %q (%v) or %q (%v)", tt.pattern, tt.better, ok1, tt.worse, ok2)
+		}
+		if better.Score <= worse.Score {
+			t.Errorf("fuzzyColumns(%q): %q = %d, want more than %q = %d", tt.pattern, tt.better, better.Score, tt.worse, worse.Score)
+		}
+	}
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	f, err := ParseFilter("?Web NGX")
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	if !f.IsFuzzy() || f.Fuzzy != "web ngx" {
+		t.Fatalf("Fuzzy = %q, want %q", f.Fuzzy, "web ngx")
+	}
+	if got := f.String(); got != "?web ngx" {
+		t.Errorf("String() = %q, want %q", got, "?web ngx")
+	}
+
+	web := docker.ContainerInfo{Name: "web", Image: "nginx:latest", State: "running"}
+	db := docker.ContainerInfo{Name: "db", Image: "postgres:16", State: "running"}
+	if !f.MatchContainer(web) {
+		t.Error("expected web to match: each term matches a different column")
+	}
+	if f.MatchContainer(db) {
+		t.Error("expected db not to match")
+	}
+	m, ok := f.FuzzyContainer(web)
+	if !ok || m.Score <= 0 {
+		t.Errorf("FuzzyContainer(web) = %v, %v; want a positive score", m, ok)
+	}
+	if got := m.Ranges("nginx:latest"); !reflect.DeepEqual(got, [][2]int{{0, 2}, {4, 5}}) {
+		t.Errorf("Ranges(image) = %v", got)
+	}
+	if got := m.Ranges("web"); !reflect.DeepEqual(got, [][2]int{{0, 3}}) {
+		t.Errorf("Ranges(name) = %v", got)
+	}
+	if got := m.Ranges("running"); got != nil {
+		t.Errorf("Ranges(state) = %v, want none: no term scored there", got)
+	}
+	if got := f.MatchRanges("nginx:latest", false); got != nil {
+		t.Errorf("MatchRanges = %v, want none for a fuzzy filter", got)
+	}
+
+	byID, err := ParseFilter("?3f2a")
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	if _, ok := byID.FuzzyContainer(docker.ContainerInfo{ID: "3f2a9c1d7e5b8a6f4c2d", Name: "db"}); !ok {
+		t.Error("expected a fuzzy search to match the container ID")
+	}
+
+	empty, err := ParseFilter("  ?  ")
+	if err != nil || !empty.IsEmpty() {
+		t.Errorf("ParseFilter(\"?\") = %v, %v; want an empty filter", empty, err)
+	}
+	if _, options := Complete("?we", ResourceContainers, CompletionData{}); options != nil {
+		t.Errorf("Complete on a fuzzy search = %v, want none", options)
+	}
+}
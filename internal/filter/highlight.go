@@ -0,0 +1,127 @@
+package filter
+
+import (
+	"regexp"
+	"sort"
+)
+
+// highlighter marks text a filter matched on: search terms in every
+// searchable column, and positive string criteria (=, ~, =~) in the column
+// of their field. Terms under a not never highlight, and neither do label
+// or inspect path criteria since no column is tied to them.
+type highlighter struct {
+	pattern *regexp.Regexp
+	field   FilterType // empty for search terms
+}
+
+// MatchRanges returns the sorted, non-overlapping byte ranges of text that
+// the filter matched. searchable reports whether free-text search terms
+// apply to the column; fields are the filter fields the column shows. A
+// fuzzy filter marks nothing here: what it matched depends on the row, so
+// its ranges come from the row's FuzzyMatch.
+func (f *Filter) MatchRanges(text string, searchable bool, fields ...FilterType) [][2]int {
+	if text == "" || f.Fuzzy != "" {
+		return nil
+	}
+	if f.highlights == nil {
+		f.highlights = f.collectHighlights()
+	}
+
+	var ranges [][2]int
+	for _, h := range f.highlights {
+		if h.field == "" && !searchable {
+			continue
+		}
+		if h.field != "" && !containsField(fields, h.field) {
+			continue
+		}
+		for _, loc := range h.pattern.FindAllStringIndex(text, -1) {
+			if loc[1] > loc[0] {
+				ranges = append(ranges, [2]int{loc[0], loc[1]})
+			}
+		}
+	}
+	return mergeRanges(ranges)
+}
+
+func (f *Filter) collectHighlights() []highlighter {
+	highlights := []highlighter{}
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *AndNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *OrNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *SearchNode:
+			highlights = append(highlights, highlighter{pattern: regexp.MustCompile("(?i)" + regexp.QuoteMeta(n.Term))})
+		case *CriterionNode:
+			if h, ok := criterionHighlight(n.Criterion); ok {
+				highlights = append(highlights, h)
+			}
+		}
+	}
+
+	if f.Expr != nil {
+		walk(f.Expr)
+		return highlights
+	}
+	if f.SearchTerm != "" {
+		walk(&SearchNode{Term: f.SearchTerm})
+	}
+	for _, c := range f.Criteria {
+		walk(&CriterionNode{Criterion: c})
+	}
+	return highlights
+}
+
+func criterionHighlight(c Criterion) (highlighter, bool) {
+	if c.Type == FilterLabel || c.Type == FilterInspect {
+		return highlighter{}, false
+	}
+	switch c.Op {
+	case OpEqual:
+		if c.Value == "" {
+			return highlighter{}, false
+		}
+		return highlighter{pattern: regexp.MustCompile("(?i)^" + regexp.QuoteMeta(c.Value) + "$"), field: c.Type}, true
+	case OpContains, OpRegex:
+		if c.Regex == nil {
+			return highlighter{}, false
+		}
+		return highlighter{pattern: c.Regex, field: c.Type}, true
+	}
+	return highlighter{}, false
+}
+
+func containsField(fields []FilterType, t FilterType) bool {
+	for _, f := range fields {
+		if f == t {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeRanges(ranges [][2]int) [][2]int {
+	if len(ranges) < 2 {
+		return ranges
+	}
+	sort.Slice(ranges, func(i, j int) bool { return ranges[i][0] < ranges[j][0] })
+	merged := ranges[:1]
+	for _, r := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if r[0] <= last[1] {
+			if r[1] > last[1] {
+				last[1] = r[1]
+			}
+			continue
+		}
+		merged = append(merged, r)
+	}
+	return merged
+}
@@ -0,0 +1,40 @@
+package filter
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchRanges(t *testing.T) {
+	tests := []struct {
+		name       string
+		filter     string
+		text       string
+		searchable bool
+		fields     []FilterType
+		want       [][2]int
+	}{
+		{"search term", "redis", "my-Redis-redis", true, nil, [][2]int{{3, 8}, {9, 14}}},
+		{"search term in non-searchable column", "redis", "redis", false, []FilterType{FilterSubnet}, nil},
+		{"contains criterion in its column", "name~web", "web-web", true, []FilterType{FilterName}, [][2]int{{0, 3}, {4, 7}}},
+		{"criterion in another column", "name~web", "web", true, []FilterType{FilterDriver}, nil},
+		{"equality", "driver=bridge", "Bridge", true, []FilterType{FilterDriver}, [][2]int{{0, 6}}},
+		{"regex", "name=~^w.b", "web-1", true, []FilterType{FilterName}, [][2]int{{0, 3}}},
+		{"overlapping ranges merge", "we or name~eb", "web", true, []FilterType{FilterName}, [][2]int{{0, 3}}},
+		{"negated terms do not highlight", "not web", "web", true, []FilterType{FilterName}, nil},
+		{"not equal does not highlight", "name!=web", "web", true, []FilterType{FilterName}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter() error = %v", err)
+			}
+			got := f.MatchRanges(tt.text, tt.searchable, tt.fields...)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchRanges(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+package filter
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"dock-it/internal/docker"
+)
+
+// InspectSource provides the decoded inspect JSON that path criteria such
+// as .HostConfig.Privileged=true are evaluated against. ok is false while
+// the resource has not been inspected yet.
+type InspectSource interface {
+	Inspected(resource Resource, id string) (data any, ok bool)
+}
+
+// pathStep is one step of an inspect path: a key (.Name or [name]), an
+// index ([0], negative from the end) or [] for every element.
+type pathStep struct {
+	key     string
+	index   int
+	isIndex bool
+	all     bool
+}
+
+// parseInspectPath parses a path like .Mounts[].Source or
+// .NetworkSettings.Networks[bridge].IPAddress.
+func parseInspectPath(path string) ([]pathStep, error) {
+	if path == "." {
+		return nil, fmt.Errorf("missing path after \".\"")
+	}
+	var steps []pathStep
+	for i := 0; i < len(path); {
+		switch path[i] {
+		case '.':
+			end := i + 1
+			for end < len(path) && path[end] != '.' && path[end] != '[' {
+				end++
+			}
+			if end == i+1 {
+				return nil, fmt.Errorf("empty key at %q", path[i:])
+			}
+			steps = append(steps, pathStep{key: path[i+1 : end]})
+			i = end
+		case '[':
+			end := strings.IndexByte(path[i:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ']' in %q", path[i:])
+			}
+			inner := path[i+1 : i+end]
+			switch n, err := strconv.Atoi(inner); {
+			case inner == "":
+				steps = append(steps, pathStep{all: true})
+			case err == nil:
+				steps = append(steps, pathStep{key: inner, index: n, isIndex: true})
+			default:
+				steps = append(steps, pathStep{key: inner})
+			}
+			i += end + 1
+		default:
+			return nil, fmt.Errorf("expected '.' or '[' at %q", path[i:])
+		}
+	}
+	return steps, nil
+}
+
+// walkPath returns the values path selects from data. A key matches a
+// field exactly, or else case-insensitively; [] expands lists and objects.
+func walkPath(data any, steps []pathStep) []any {
+	values := []any{data}
+	for _, step := range steps {
+		var next []any
+		for _, v := range values {
+			switch v := v.(type) {
+			case map[string]any:
+				if step.all {
+					for _, child := range v {
+						next = append(next, child)
+					}
+				} else if child, ok := lookupKey(v, step.key); ok {
+					next = append(next, child)
+				}
+			case []any:
+				if step.all {
+					next = append(next, v...)
+				} else if step.isIndex {
+					i := step.index
+					if i < 0 {
+						i += len(v)
+					}
+					if i >= 0 && i < len(v) {
+						next = append(next, v[i])
+					}
+				}
+			}
+		}
+		values = next
+	}
+	return values
+}
+
+func lookupKey(m map[string]any, key string) (any, bool) {
+	if v, ok := m[key]; ok {
+		return v, true
+	}
+	for k, v := range m {
+		if strings.EqualFold(k, key) {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// matchInspect evaluates a path criterion against the resource's inspect
+// data. Like labels, a path that selects nothing only satisfies != and !~,
+// which match when no selected value satisfies = or ~. A bare path matches
+// when any value is set: not null, false, 0, "" or empty. Callers only
+// evaluate it once the resource is inspected.
+func (f *Filter) matchInspect(resource Resource, id string, c Criterion) bool {
+	if f.Inspect == nil {
+		return false
+	}
+	data, ok := f.Inspect.Inspected(resource, id)
+	if !ok {
+		return false
+	}
+	values := walkPath(data, c.path)
+
+	switch c.Op {
+	case OpExists:
+		for _, v := range values {
+			if isTruthy(v) {
+				return true
+			}
+		}
+		return false
+	case OpNotEqual, OpNotContains:
+		positive := c
+		positive.Op = OpEqual
+		if c.Op == OpNotContains {
+			positive.Op = OpContains
+		}
+		return !matchJSONValues(values, positive)
+	}
+	return matchJSONValues(values, c)
+}
+
+func matchJSONValues(values []any, c Criterion) bool {
+	for _, v := range values {
+		text := jsonText(v)
+		if isOrdering(c.Op) {
+			actual, errA := strconv.ParseFloat(text, 64)
+			expected, errE := strconv.ParseFloat(c.Value, 64)
+			if errA == nil && errE == nil {
+				if compareNumeric(actual, c.Op, expected) {
+					return true
+				}
+				continue
+			}
+			if compareNumeric(float64(strings.Compare(text, c.Value)), c.Op, 0) {
+				return true
+			}
+			continue
+		}
+		if compareString(text, c.Op, c.Value, c.Regex) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonText renders a JSON value for comparison: strings as themselves,
+// numbers without trailing zeros, null as "", and objects and lists as
+// compact JSON.
+func jsonText(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return string(raw)
+}
+
+func isTruthy(v any) bool {
+	switch v := v.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	case map[string]any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// NeedsInspect reports whether the filter has path criteria, which need
+// inspect data.
+func (f *Filter) NeedsInspect() bool {
+	if f.Fuzzy != "" {
+		return false
+	}
+	found := false
+	var walk func(n Node)
+	walk = func(n Node) {
+		switch n := n.(type) {
+		case *AndNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *OrNode:
+			for _, child := range n.Children {
+				walk(child)
+			}
+		case *NotNode:
+			walk(n.Child)
+		case *CriterionNode:
+			found = found || n.Criterion.Type == FilterInspect
+		}
+	}
+	walk(f.root())
+	return found
+}
+
+// InspectPendingContainer reports whether the container still needs to be
+// inspected: the filter has path criteria, the container has no inspect
+// data yet, and whether it matches depends on them.
+func (f *Filter) InspectPendingContainer(c docker.ContainerInfo) bool {
+	return f.inspectPending(ResourceContainers, c.ID, func(term string) bool {
+		return matchContainerSearch(c, term)
+	}, func(criterion Criterion) bool {
+		return matchContainerCriterion(c, criterion)
+	})
+}
+
+// InspectPendingImage is InspectPendingContainer for images.
+func (f *Filter) InspectPendingImage(img docker.ImageInfo) bool {
+	return f.inspectPending(ResourceImages, img.ID, func(term string) bool {
+		return matchImageSearch(img, term)
+	}, func(criterion Criterion) bool {
+		return matchImageCriterion(img, criterion)
+	})
+}
+
+// InspectPendingNetwork is InspectPendingContainer for networks.
+func (f *Filter) InspectPendingNetwork(net docker.NetworkInfo) bool {
+	return f.inspectPending(ResourceNetworks, net.ID, func(term string) bool {
+		return matchNetworkSearch(net, term)
+	}, func(criterion Criterion) bool {
+		return matchNetworkCriterion(net, criterion)
+	})
+}
+
+// InspectPendingVolume is InspectPendingContainer for volumes.
+func (f *Filter) InspectPendingVolume(vol docker.VolumeInfo) bool {
+	return f.inspectPending(ResourceVolumes, vol.Name, func(term string) bool {
+		return matchVolumeSearch(vol, term)
+	}, func(criterion Criterion) bool {
+		return matchVolumeCriterion(vol, criterion)
+	})
+}
+
+func (f *Filter) inspectPending(resource Resource, id string, search func(string) bool, criterion func(Criterion) bool) bool {
+	if !f.NeedsInspect() || f.inspected(resource, id) {
+		return false
+	}
+	return f.evalUninspected(search, criterion) == matchUnknown
+}
+
+// matchRow evaluates the filter on a row of resource. Until the row is
+// inspected its path criteria are unknown rather than false, so it only
+// matches when the rest of the filter decides it does; otherwise a negated
+// path criterion would show it before its inspect data says whether it
+// should be.
+func (f *Filter) matchRow(resource Resource, id string, search func(string) bool, criterion func(Criterion) bool) bool {
+	if f.NeedsInspect() && !f.inspected(resource, id) {
+		return f.evalUninspected(search, criterion) == matchYes
+	}
+	return f.match(search, func(c Criterion) bool {
+		if c.Type == FilterInspect {
+			return f.matchInspect(resource, id, c)
+		}
+		return criterion(c)
+	})
+}
+
+func (f *Filter) inspected(resource Resource, id string) bool {
+	if f.Inspect == nil {
+		return false
+	}
+	_, ok := f.Inspect.Inspected(resource, id)
+	return ok
+}
+
+// evalUninspected evaluates the filter with its path criteria unknown.
+func (f *Filter) evalUninspected(search func(string) bool, criterion func(Criterion) bool) partialMatch {
+	m := matcher{search: search, criterion: criterion}
+	known := func(c Criterion) bool { return c.Type != FilterInspect }
+	return evalPartial(f.root(), m, known)
+}
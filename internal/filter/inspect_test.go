@@ -0,0 +1,173 @@
+package filter
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"dock-it/internal/docker"
+)
+
+// inspectData is an InspectSource backed by JSON documents keyed by ID.
+type inspectData map[string]string
+
+func (d inspectData) Inspected(resource Resource, id string) (any, bool) {
+	doc, ok := d[id]
+	if !ok {
+		return nil, false
+	}
+	var data any
+	if err := json.Unmarshal([]byte(doc), &data); err != nil {
+		panic(err)
+	}
+	return data, true
+}
+
+func TestMatchInspect(t *testing.T) {
+	source := inspectData{
+		"priv": `{"HostConfig": {"Privileged": true, "NetworkMode": "host", "Memory": 0},
+			"Mounts": [{"Source": "/var/run/docker.sock", "RW": true}, {"Source": "/data", "RW": false}],
+			"NetworkSettings": {"Networks": {"bridge": {"IPAddress": "172.17.0.2"}}},
+			"Config": {"User": "", "Env": ["A=1", "B=2"]}}`,
+		"plain": `{"HostConfig": {"Privileged": false, "NetworkMode": "default", "Memory": 536870912},
+			"Mounts": [], "Config": {"User": "app", "Env": null}}`,
+	}
+	priv := docker.ContainerInfo{ID: "priv", Name: "priv"}
+	plain := docker.ContainerInfo{ID: "plain", Name: "plain"}
+
+	tests := []struct {
+		filter    string
+		wantPriv  bool
+		wantPlain bool
+	}{
+		{".HostConfig.Privileged=true", true, false},
+		{".hostconfig.privileged=TRUE", true, false},
+		{".HostConfig.Privileged", true, false},
+		{"not .HostConfig.Privileged", false, true},
+		{".HostConfig.NetworkMode=host", true, false},
+		{".Mounts[].Source~docker.sock", true, false},
+		{".Mounts[].Source!~docker.sock", false, true},
+		{".Mounts[0].RW=true", true, false},
+		{".Mounts[-1].Source=/data", true, false},
+		{".NetworkSettings.Networks[bridge].IPAddress~172.17", true, false},
+		{".NetworkSettings.Networks[].IPAddress=~^172\\.", true, false},
+		{".HostConfig.Memory>1000", false, true},
+		{".Config.User=", true, false},
+		{".Config.User!=app", true, false},
+		{".Config.Env[]=B=2", true, false},
+		{".Missing.Path=x", false, false},
+		{".Missing.Path!=x", true, true},
+		{".HostConfig.Privileged=true or name=plain", true, true},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilterFor(tt.filter, ResourceContainers)
+		if err != nil {
+			t.Fatalf("ParseFilterFor(%q): %v", tt.filter, err)
+		}
+		f.Inspect = source
+		if got := f.MatchContainer(priv); got != tt.wantPriv {
+			t.Errorf("%q on priv = %v, want %v", tt.filter, got, tt.wantPriv)
+		}
+		if got := f.MatchContainer(plain); got != tt.wantPlain {
+			t.Errorf("%q on plain = %v, want %v", tt.filter, got, tt.wantPlain)
+		}
+	}
+}
+
+func TestInspectPending(t *testing.T) {
+	source := inspectData{"done": `{}`}
+	web := docker.ContainerInfo{ID: "web", Name: "web"}
+	db := docker.ContainerInfo{ID: "db", Name: "db"}
+	done := docker.ContainerInfo{ID: "done", Name: "web-done"}
+
+	f, err := ParseFilterFor("name~web, .HostConfig.Privileged", ResourceContainers)
+	if err != nil {
+		t.Fatalf("ParseFilterFor: %v", err)
+	}
+	f.Inspect = source
+	if !f.NeedsInspect() {
+		t.Fatal("NeedsInspect() = false, want true")
+	}
+	if !f.InspectPendingContainer(web) {
+		t.Error("web should be pending: it matches name~web and is not inspected")
+	}
+	if f.InspectPendingContainer(db) {
+		t.Error("db should not be pending: name~web already rules it out")
+	}
+	if f.InspectPendingContainer(done) {
+		t.Error("done should not be pending: it is inspected")
+	}
+	if f.MatchContainer(web) {
+		t.Error("containers that are not inspected yet should not match")
+	}
+
+	plain, _ := ParseFilter("name~web")
+	if plain.NeedsInspect() || plain.InspectPendingContainer(web) {
+		t.Error("a filter without paths should never need inspect data")
+	}
+}
+
+func TestInspectPendingNegated(t *testing.T) {
+	source := inspectData{"plain": `{"HostConfig": {"Privileged": false}}`}
+	web := docker.ContainerInfo{ID: "web", Name: "web", State: "running"}
+	plain := docker.ContainerInfo{ID: "plain", Name: "plain", State: "running"}
+
+	f, err := ParseFilterFor("not .HostConfig.Privileged=true", ResourceContainers)
+	if err != nil {
+		t.Fatalf("ParseFilterFor: %v", err)
+	}
+	f.Inspect = source
+	if f.MatchContainer(web) {
+		t.Error("web should be hidden until inspected: it may be privileged")
+	}
+	if !f.InspectPendingContainer(web) {
+		t.Error("web should be pending: the negated path decides whether it matches")
+	}
+	if !f.MatchContainer(plain) || f.InspectPendingContainer(plain) {
+		t.Error("plain is inspected and not privileged, so it should match and not be pending")
+	}
+
+	// The rest of the filter decides without inspect data.
+	decided, err := ParseFilterFor("state=running or not .HostConfig.Privileged=true", ResourceContainers)
+	if err != nil {
+		t.Fatalf("ParseFilterFor: %v", err)
+	}
+	decided.Inspect = source
+	if !decided.MatchContainer(web) || decided.InspectPendingContainer(web) {
+		t.Error("web matches state=running, so it should show without waiting for inspect data")
+	}
+}
+
+func TestParseInspectCriteria(t *testing.T) {
+	for _, input := range []string{
+		".HostConfig.Privileged=true",
+		".Mounts[].Source~docker.sock",
+		".NetworkSettings.Networks[bridge].IPAddress",
+		"not .Config.User, name=web",
+		`".env" or .Config.User=""`,
+	} {
+		f, err := ParseFilterFor(input, ResourceContainers)
+		if err != nil {
+			t.Errorf("ParseFilterFor(%q): %v", input, err)
+			continue
+		}
+		if got := f.String(); got != input {
+			t.Errorf("ParseFilterFor(%q).String() = %q", input, got)
+		}
+	}
+
+	errors := []struct {
+		input string
+		want  string
+	}{
+		{".Mounts[.Source=x", "column 1: missing ']'"},
+		{".Mounts..Source", "column 1: empty key"},
+		{"name=web .HostConfig[", "column 10: missing ']'"},
+	}
+	for _, tt := range errors {
+		_, err := ParseFilterFor(tt.input, ResourceContainers)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseFilterFor(%q) error = %v, want it to contain %q", tt.input, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies the lexical class of a token in a filter expression.
+type tokenKind int
+
+const (
+	tokEOF tokenKind = iota
+	tokLParen
+	tokRParen
+	tokComma
+	tokAnd
+	tokOr
+	tokNot
+	tokWord
+	tokString
+	tokOp
+	tokValue
+)
+
+func (k tokenKind) String() string {
+	switch k {
+	case tokEOF:
+		return "end of input"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokComma:
+		return "','"
+	case tokAnd:
+		return "'and'"
+	case tokOr:
+		return "'or'"
+	case tokNot:
+		return "'not'"
+	case tokWord:
+		return "word"
+	case tokString:
+		return "quoted string"
+	case tokOp:
+		return "operator"
+	case tokValue:
+		return "value"
+	default:
+		return "token"
+	}
+}
+
+// token is a lexeme with its byte offset in the input.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// SyntaxError reports a malformed filter expression. Pos is the byte offset
+// of the offending token in the input.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Pos+1, e.Msg)
+}
+
+// operators lists the comparison operators longest first so that the lexer
+// never splits ">=" into ">" and "=".
+var operators = []ComparisonOp{OpGreaterEqual, OpLessEqual, OpNotEqual, OpNotContains, OpRegex, OpEqual, OpGreater, OpLess, OpContains}
+
+// lex splits a filter expression into tokens. The value after an operator is
+// read in a separate mode so it may contain characters that are otherwise
+// significant, e.g. name=~^web-(a|b)$; it ends at a comma, an unbalanced
+// ')' or whitespace before something that is not part of it (see
+// continuesValue). Values and search terms may be quoted with ' or ".
+func lex(input string) ([]token, error) {
+	var tokens []token
+	i := 0
+	for {
+		for i < len(input) && unicode.IsSpace(rune(input[i])) {
+			i++
+		}
+		if i >= len(input) {
+			tokens = append(tokens, token{kind: tokEOF, pos: i})
+			return tokens, nil
+		}
+
+		start := i
+		switch ch := input[i]; {
+		case ch == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: start})
+			i++
+		case ch == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: start})
+			i++
+		case ch == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: start})
+			i++
+		case strings.HasPrefix(input[i:], "&&"):
+			tokens = append(tokens, token{kind: tokAnd, text: "&&", pos: start})
+			i += 2
+		case strings.HasPrefix(input[i:], "||"):
+			tokens = append(tokens, token{kind: tokOr, text: "||", pos: start})
+			i += 2
+		case ch == '"' || ch == '\'':
+			text, end, err := lexQuoted(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokString, text: text, pos: start})
+			i = end
+		case isOperatorChar(ch):
+			op := matchOperator(input[i:])
+			if op == "" {
+				if ch == '!' {
+					tokens = append(tokens, token{kind: tokNot, text: "!", pos: start})
+					i++
+					continue
+				}
+				return nil, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected %q", ch)}
+			}
+			tokens = append(tokens, token{kind: tokOp, text: string(op), pos: start})
+			i += len(op)
+
+			value, end, err := lexValue(input, i)
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, value)
+			i = end
+		default:
+			for i < len(input) && isWordChar(input, i) {
+				i++
+			}
+			word := input[start:i]
+			kind := tokWord
+			switch strings.ToLower(word) {
+			case "and":
+				kind = tokAnd
+			case "or":
+				kind = tokOr
+			case "not":
+				kind = tokNot
+			}
+			tokens = append(tokens, token{kind: kind, text: word, pos: start})
+		}
+	}
+}
+
+// lexValue reads the value following an operator starting at i. Leading
+// blanks are skipped so "age > 1h" is accepted, unless a keyword follows
+// them: "label.x= or ..." compares with an empty value. An empty value is
+// returned as an empty token and rejected later by the criterion parser
+// where it is not allowed.
+func lexValue(input string, i int) (token, int, error) {
+	opEnd := i
+	for i < len(input) && (input[i] == ' ' || input[i] == '\t') {
+		i++
+	}
+	if i > opEnd {
+		end := i
+		for end < len(input) && isWordChar(input, end) {
+			end++
+		}
+		if isKeyword(input[i:end]) {
+			return token{kind: tokValue, pos: opEnd}, opEnd, nil
+		}
+	}
+	if i < len(input) && (input[i] == '"' || input[i] == '\'') {
+		text, end, err := lexQuoted(input, i)
+		if err != nil {
+			return token{}, 0, err
+		}
+		return token{kind: tokValue, text: text, pos: i}, end, nil
+	}
+
+	start, depth := i, 0
+	for ; i < len(input); i++ {
+		ch := input[i]
+		if ch == ',' {
+			break
+		}
+		if unicode.IsSpace(rune(ch)) {
+			if !continuesValue(input, i) {
+				break
+			}
+			continue
+		}
+		if ch == '(' {
+			depth++
+		}
+		if ch == ')' {
+			if depth == 0 {
+				break
+			}
+			depth--
+		}
+	}
+	return token{kind: tokValue, text: input[start:i], pos: start}, i, nil
+}
+
+// continuesValue reports whether the blanks at i are inside an unquoted
+// value: name~my app compares with "my app" as the comma syntax always
+// has. The value ends before a keyword, a comma, a parenthesis, a quote, an
+// operator, or a word that starts the next criterion (state=running
+// name~web, or a bare inspect path or label).
+func continuesValue(input string, i int) bool {
+	for i < len(input) && unicode.IsSpace(rune(input[i])) {
+		i++
+	}
+	end := i
+	for end < len(input) && isWordChar(input, end) {
+		end++
+	}
+	word := input[i:end]
+	if word == "" || isKeyword(word) || startsBareCriterion(word) {
+		return false
+	}
+	for end < len(input) && (input[end] == ' ' || input[end] == '\t') {
+		end++
+	}
+	return end == len(input) || !isOperatorChar(input[end])
+}
+
+// lexQuoted reads a string quoted with input[i]. A backslash escapes the
+// quote character and itself; other backslashes are kept as written so
+// regular expressions can be quoted verbatim.
+func lexQuoted(input string, i int) (string, int, error) {
+	quote := input[i]
+	var b strings.Builder
+	for j := i + 1; j < len(input); j++ {
+		ch := input[j]
+		switch {
+		case ch == '\\' && j+1 < len(input) && (input[j+1] == quote || input[j+1] == '\\'):
+			b.WriteByte(input[j+1])
+			j++
+		case ch == quote:
+			return b.String(), j + 1, nil
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	return "", 0, &SyntaxError{Pos: i, Msg: "unterminated quoted string"}
+}
+
+// startsBareCriterion reports whether word is a criterion without an
+// operator: an inspect path or a label key.
+func startsBareCriterion(word string) bool {
+	return strings.HasPrefix(word, ".") || strings.HasPrefix(strings.ToLower(word), "label.")
+}
+
+func isOperatorChar(ch byte) bool {
+	return strings.IndexByte("=!<>~", ch) >= 0
+}
+
+func matchOperator(s string) ComparisonOp {
+	for _, op := range operators {
+		if strings.HasPrefix(s, string(op)) {
+			return op
+		}
+	}
+	return ""
+}
+
+func isWordChar(input string, i int) bool {
+	ch := input[i]
+	if unicode.IsSpace(rune(ch)) || isOperatorChar(ch) || strings.IndexByte(`(),"'`, ch) >= 0 {
+		return false
+	}
+	return !strings.HasPrefix(input[i:], "&&") && !strings.HasPrefix(input[i:], "||")
+}
+
+// quoteValue renders s so that lex reads it back as a single token.
+func quoteValue(s string) string {
+	if s != "" && !strings.ContainsAny(s, " \t\n,()\"'") {
+		return s
+	}
+	return quote(s)
+}
+
+// quote wraps s in double quotes, escaping the characters lexQuoted treats
+// specially.
+func quote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
@@ -0,0 +1,381 @@
+package filter
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Node is a node of a parsed filter expression.
+type Node interface {
+	// String renders the node so that ParseFilter reads it back unchanged.
+	String() string
+	eval(m matcher) bool
+}
+
+// matcher evaluates the leaves of an expression against one resource.
+type matcher struct {
+	search    func(term string) bool
+	criterion func(c Criterion) bool
+}
+
+// AndNode matches when every child matches.
+type AndNode struct {
+	Children []Node
+}
+
+// OrNode matches when any child matches.
+type OrNode struct {
+	Children []Node
+}
+
+// NotNode inverts its child.
+type NotNode struct {
+	Child Node
+}
+
+// CriterionNode is a single field comparison such as age>1h.
+type CriterionNode struct {
+	Criterion Criterion
+}
+
+// SearchNode is a free-text term matched as a case-insensitive substring
+// of the resource's main fields. Term is stored lower-cased.
+type SearchNode struct {
+	Term string
+}
+
+func (n *AndNode) eval(m matcher) bool {
+	for _, child := range n.Children {
+		if !child.eval(m) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *OrNode) eval(m matcher) bool {
+	for _, child := range n.Children {
+		if child.eval(m) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n *NotNode) eval(m matcher) bool       { return !n.Child.eval(m) }
+func (n *CriterionNode) eval(m matcher) bool { return m.criterion(n.Criterion) }
+func (n *SearchNode) eval(m matcher) bool    { return m.search(n.Term) }
+func (n *CriterionNode) String() string      { return n.Criterion.String() }
+func (n *NotNode) String() string            { return "not " + group(n.Child) }
+func (n *OrNode) String() string             { return joinNodes(n.Children, " or ") }
+func (n *AndNode) String() string            { return joinNodes(n.Children, ", ") }
+func (n *SearchNode) String() string         { return searchString(n.Term) }
+
+func joinNodes(nodes []Node, sep string) string {
+	parts := make([]string, len(nodes))
+	for i, n := range nodes {
+		if _, ok := n.(*OrNode); ok && sep != " or " {
+			parts[i] = "(" + n.String() + ")"
+			continue
+		}
+		parts[i] = n.String()
+	}
+	return strings.Join(parts, sep)
+}
+
+// group parenthesizes compound nodes so a prefix operator applies to all
+// of them.
+func group(n Node) string {
+	switch n.(type) {
+	case *AndNode, *OrNode:
+		return "(" + n.String() + ")"
+	}
+	return n.String()
+}
+
+// searchString renders a search term bare when the lexer would read it back
+// as the same run of plain words, and quoted otherwise.
+func searchString(term string) string {
+	for _, word := range strings.Fields(term) {
+		if _, ok := strings.CutPrefix(word, string(FilterLabel)+"."); ok || strings.HasPrefix(word, ".") || isKeyword(word) ||
+			strings.ContainsAny(word, `=!<>~(),"'`) || strings.Contains(word, "&&") || strings.Contains(word, "||") {
+			return quote(term)
+		}
+	}
+	if strings.TrimSpace(term) != term || term == "" {
+		return quote(term)
+	}
+	return term
+}
+
+func isKeyword(word string) bool {
+	switch strings.ToLower(word) {
+	case "and", "or", "not":
+		return true
+	}
+	return false
+}
+
+// String renders the criterion in filter syntax.
+func (c Criterion) String() string {
+	field := string(c.Type)
+	switch c.Type {
+	case FilterLabel:
+		field += "." + c.Key
+	case FilterInspect:
+		field = c.Key
+	}
+	if c.Op == OpExists {
+		return field
+	}
+	return field + string(c.Op) + quoteValue(c.Value)
+}
+
+// parser is a recursive descent parser over the tokens of one expression:
+//
+//	or      = and { ("or" | "||") and }
+//	and     = unary { ["and" | "&&" | ","] unary }
+//	unary   = ("not" | "!") unary | primary
+//	primary = "(" or ")" | field op value | "label."key | search
+//
+// Juxtaposed terms are ANDed, so "state=running web" needs no keyword.
+// Consecutive bare words form a single search phrase.
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+	check  validator
+}
+
+// parseExpr parses input into an expression tree, validating criteria
+// against the fields of resource. It returns nil for input that holds no
+// terms.
+func parseExpr(input string, resource Resource) (Node, error) {
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{input: input, tokens: tokens, check: validator{resource: resource}}
+	p.skipCommas()
+	if p.peek().kind == tokEOF {
+		return nil, nil
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, p.unexpected(t)
+	}
+	return node, nil
+}
+
+func (p *parser) peek() token { return p.tokens[p.pos] }
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokEOF {
+		p.pos++
+	}
+	return t
+}
+
+// skipCommas drops empty list entries, which the comma shorthand has
+// always tolerated (e.g. a trailing comma).
+func (p *parser) skipCommas() {
+	for p.peek().kind == tokComma {
+		p.next()
+	}
+}
+
+func (p *parser) unexpected(t token) error {
+	if t.kind == tokEOF {
+		return &SyntaxError{Pos: t.pos, Msg: "unexpected end of filter"}
+	}
+	return &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %s %q", t.kind, t.text)}
+}
+
+func (p *parser) parseOr() (Node, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	children := []Node{first}
+	for p.peek().kind == tokOr {
+		op := p.next()
+		if p.peek().kind == tokEOF {
+			return nil, &SyntaxError{Pos: op.pos, Msg: fmt.Sprintf("missing expression after %q", op.text)}
+		}
+		next, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+	if len(children) == 1 {
+		return first, nil
+	}
+	return &OrNode{Children: children}, nil
+}
+
+func (p *parser) parseAnd() (Node, error) {
+	first, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	children := []Node{first}
+	for {
+		switch t := p.peek(); t.kind {
+		case tokComma:
+			p.skipCommas()
+			if k := p.peek().kind; k == tokEOF || k == tokRParen || k == tokOr {
+				return makeAnd(children), nil
+			}
+		case tokAnd:
+			p.next()
+			if p.peek().kind == tokEOF {
+				return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("missing expression after %q", t.text)}
+			}
+		case tokLParen, tokNot, tokWord, tokString:
+			// Juxtaposition is an implicit AND.
+		default:
+			return makeAnd(children), nil
+		}
+		next, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		children = append(children, next)
+	}
+}
+
+func makeAnd(children []Node) Node {
+	if len(children) == 1 {
+		return children[0]
+	}
+	return &AndNode{Children: children}
+}
+
+func (p *parser) parseUnary() (Node, error) {
+	if t := p.peek(); t.kind == tokNot {
+		p.next()
+		if p.peek().kind == tokEOF {
+			return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("missing expression after %q", t.text)}
+		}
+		child, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &NotNode{Child: child}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (Node, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokLParen:
+		p.next()
+		if p.peek().kind == tokRParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: "empty parentheses"}
+		}
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek().kind != tokRParen {
+			return nil, &SyntaxError{Pos: p.peek().pos, Msg: fmt.Sprintf("missing ')' to close '(' at column %d", t.pos+1)}
+		}
+		p.next()
+		return node, nil
+	case tokString:
+		p.next()
+		return &SearchNode{Term: strings.ToLower(t.text)}, nil
+	case tokWord:
+		if p.peekAt(1).kind == tokOp {
+			return p.parseCriterion()
+		}
+		if key, ok := strings.CutPrefix(t.text, string(FilterLabel)+"."); ok && key != "" {
+			if _, err := p.check.field(t.text); err != nil {
+				return nil, &SyntaxError{Pos: t.pos, Msg: err.Error()}
+			}
+			p.next()
+			return &CriterionNode{Criterion: Criterion{Type: FilterLabel, Op: OpExists, Key: key}}, nil
+		}
+		if len(t.text) > 1 && strings.HasPrefix(t.text, ".") {
+			return p.parseInspectExists()
+		}
+		return p.parseSearch(), nil
+	case tokOp:
+		return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("missing field before %q", t.text)}
+	}
+	return nil, p.unexpected(t)
+}
+
+func (p *parser) parseCriterion() (Node, error) {
+	field := p.next()
+	op := p.next()
+	value := p.next()
+
+	if key, ok := strings.CutPrefix(field.text, string(FilterLabel)+"."); ok && key == "" {
+		return nil, &SyntaxError{Pos: field.pos, Msg: "missing label key after \"label.\""}
+	}
+	if strings.HasPrefix(field.text, ".") {
+		if _, err := parseInspectPath(field.text); err != nil {
+			return nil, &SyntaxError{Pos: field.pos, Msg: err.Error()}
+		}
+	}
+	spec, err := p.check.field(field.text)
+	if err != nil {
+		return nil, &SyntaxError{Pos: field.pos, Msg: err.Error()}
+	}
+	if err := p.check.op(spec, ComparisonOp(op.text)); err != nil {
+		return nil, &SyntaxError{Pos: op.pos, Msg: err.Error()}
+	}
+	c, err := newCriterion(field.text, ComparisonOp(op.text), value.text)
+	if err == nil {
+		err = p.check.value(spec, c)
+	}
+	if err != nil {
+		return nil, &SyntaxError{Pos: value.pos, Msg: fmt.Sprintf("%s%s: %v", field.text, op.text, err)}
+	}
+	return &CriterionNode{Criterion: c}, nil
+}
+
+// parseInspectExists parses a bare inspect path, which matches when the
+// path selects a value that is set.
+func (p *parser) parseInspectExists() (Node, error) {
+	t := p.next()
+	path, err := parseInspectPath(t.text)
+	if err != nil {
+		return nil, &SyntaxError{Pos: t.pos, Msg: err.Error()}
+	}
+	if _, err := p.check.field(t.text); err != nil {
+		return nil, &SyntaxError{Pos: t.pos, Msg: err.Error()}
+	}
+	return &CriterionNode{Criterion: Criterion{Type: FilterInspect, Op: OpExists, Key: t.text, path: path}}, nil
+}
+
+// parseSearch joins consecutive bare words into one phrase, keeping the
+// spacing of the input, so "my container" searches for the whole phrase.
+func (p *parser) parseSearch() Node {
+	first := p.next()
+	last := first
+	for {
+		t := p.peek()
+		if t.kind != tokWord || p.peekAt(1).kind == tokOp || strings.HasPrefix(t.text, string(FilterLabel)+".") || strings.HasPrefix(t.text, ".") {
+			break
+		}
+		last = p.next()
+	}
+	term := p.input[first.pos : last.pos+len(last.text)]
+	return &SearchNode{Term: strings.ToLower(term)}
+}
@@ -0,0 +1,178 @@
+package filter
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"dock-it/internal/docker"
+)
+
+func TestParseExpressionString(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"comma shorthand", "age>1h,status=running", "age>1h, status=running"},
+		{"and keyword", "state=running and name~web", "state=running, name~web"},
+		{"juxtaposition", "state=running name~web", "state=running, name~web"},
+		{"or", "state=running or state=restarting", "state=running or state=restarting"},
+		{"and binds tighter", "state=exited or name~web, age>1h", "state=exited or name~web, age>1h"},
+		{"parentheses", "(state=running || state=restarting) && !name~test", "(state=running or state=restarting), not name~test"},
+		{"not group", "not (name~a or name~b)", "not (name~a or name~b)"},
+		{"search phrase", "My Container", "my container"},
+		{"search with criterion", "redis age>1h", "redis, age>1h"},
+		{"quoted search", `"and" or web`, `"and" or web`},
+		{"quoted value", `name="my app"`, `name="my app"`},
+		{"regex value with parens", "name=~^web-(a|b)$ state=running", `name=~"^web-(a|b)$", state=running`},
+		{"spaces around operator", "age > 1h", "age>1h"},
+		{"bare label", "label.traefik.enable or label.tier=", `label.traefik.enable or label.tier=""`},
+		{"empty label value before keyword", "label.tier= or name~web", `label.tier="" or name~web`},
+		{"trailing comma", "age>1h,", "age>1h"},
+		{"value with spaces", "name~my app", `name~"my app"`},
+		{"value with spaces then criterion", "name~my app state=running", `name~"my app", state=running`},
+		{"value with spaces then keyword", "name~my app or db", `name~"my app" or db`},
+		{"value with spaces then bare label", "name~my app label.tier", `name~"my app", label.tier`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.input)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %v", tt.input, err)
+			}
+			got := f.String()
+			if got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+			again, err := ParseFilter(got)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) round trip error = %v", got, err)
+			}
+			if again.String() != got {
+				t.Errorf("round trip String() = %q, want %q", again.String(), got)
+			}
+		})
+	}
+}
+
+func TestParseExpressionErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantPos int
+	}{
+		{"missing value", "age>", 4},
+		{"bad duration", "state=running and age>soon", 22},
+		{"unclosed paren", "(state=running or name~a", 24},
+		{"stray close paren", "state=running)", 13},
+		{"dangling or", "state=running or", 14},
+		{"dangling not", "state=running and not", 18},
+		{"missing field", "=running", 0},
+		{"empty parens", "name~a ()", 7},
+		{"unterminated quote", `name="web`, 5},
+		{"missing label key", "label.=x", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilter(tt.input)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseFilter(%q) error = %v, want *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Pos != tt.wantPos {
+				t.Errorf("ParseFilter(%q) error position = %d, want %d (%v)", tt.input, syntaxErr.Pos, tt.wantPos, err)
+			}
+		})
+	}
+}
+
+func TestMatchExpression(t *testing.T) {
+	containers := []docker.ContainerInfo{
+		{Name: "web", State: "running"},
+		{Name: "web-test", State: "running"},
+		{Name: "worker", State: "restarting"},
+		{Name: "db", State: "exited"},
+	}
+
+	tests := []struct {
+		name  string
+		input string
+		want  []string
+	}{
+		{"grouped or with not", "(state=running or state=restarting) and not name~test", []string{"web", "worker"}},
+		{"or of criteria", "state=exited || name=worker", []string{"worker", "db"}},
+		{"search term and criterion", "web state=running", []string{"web", "web-test"}},
+		{"negated search", "!web", []string{"worker", "db"}},
+		{"search or search", "db or worker", []string{"worker", "db"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.input)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error = %v", tt.input, err)
+			}
+			var got []string
+			for _, c := range containers {
+				if f.MatchContainer(c) {
+					got = append(got, c.Name)
+				}
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("matched %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("matched %v, want %v", got, tt.want)
+					break
+				}
+			}
+		})
+	}
+}
+
+func TestParseFilterForValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		resource Resource
+		wantErr  string
+		wantPos  int
+	}{
+		{"typo in field", "staus=running", ResourceContainers, `unknown field "staus" for containers (did you mean "status" or "state"?)`, 0},
+		{"field of another view", "size>1GB", ResourceContainers, `unknown field "size" for containers`, 0},
+		{"operator not valid", "name~a and state>running", ResourceContainers, "operator > is not valid for state (use = != ~ !~ =~)", 16},
+		{"bool operator", "oom~true", ResourceContainers, "operator ~ is not valid for oom (use = !=)", 3},
+		{"typo in state", "state=runing", ResourceContainers, `state=: unknown state "runing" (did you mean "running"?)`, 6},
+		{"label on volumes", "label.team=x, drver=local", ResourceVolumes, `unknown field "drver" for volumes (did you mean "driver"?)`, 14},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseFilterFor(tt.input, tt.resource)
+			var syntaxErr *SyntaxError
+			if !errors.As(err, &syntaxErr) {
+				t.Fatalf("ParseFilterFor(%q) error = %v, want *SyntaxError", tt.input, err)
+			}
+			if syntaxErr.Pos != tt.wantPos {
+				t.Errorf("error position = %d, want %d", syntaxErr.Pos, tt.wantPos)
+			}
+			if !strings.HasPrefix(syntaxErr.Msg, tt.wantErr) {
+				t.Errorf("error = %q, want prefix %q", syntaxErr.Msg, tt.wantErr)
+			}
+		})
+	}
+
+	for _, input := range []string{"state=Running, cpu>50", "state~run", "tag~ubuntu, size>1GB"} {
+		resource := ResourceContainers
+		if strings.HasPrefix(input, "tag") {
+			resource = ResourceImages
+		}
+		if _, err := ParseFilterFor(input, resource); err != nil {
+			t.Errorf("ParseFilterFor(%q) error = %v", input, err)
+		}
+	}
+}
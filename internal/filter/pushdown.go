@@ -0,0 +1,258 @@
+package filter
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/filters"
+
+	"dock-it/internal/docker"
+)
+
+// ServerFilters translates the criteria every matching row must satisfy
+// into filters the daemon applies while listing resource. The daemon may
+// return more rows than the filter matches, never fewer, so the full
+// filter is still evaluated in memory afterwards. Criteria the daemon has
+// no exact counterpart for are left out:
+//
+//   - containers: state=, name=, network=, volume= and label presence
+//   - images: dangling= and label presence
+//   - networks: name= and label presence
+//   - volumes: name=, used= and label presence
+//
+// image= is not sent as ancestor: the daemon resolves the reference to the
+// image it names now, which would drop containers of an outdated image.
+// Nor are created< and created> sent as before and since: those name a
+// container or image to compare with, not a time, and no criterion refers
+// to another resource that way.
+func (f *Filter) ServerFilters(resource Resource) filters.Args {
+	args := filters.NewArgs()
+	for _, c := range f.requiredCriteria() {
+		if _, ok := lookupField(resource, c.Type); !ok {
+			continue
+		}
+		if c.Type == FilterLabel {
+			// label.k=v compares case-insensitively here and exactly on the
+			// daemon, so only the presence of the key is pushed down.
+			if c.Op != OpNotEqual && c.Op != OpNotContains {
+				args.Add("label", c.Key)
+			}
+			continue
+		}
+
+		switch {
+		case c.Op == OpEqual && c.Type == FilterState && resource == ResourceContainers:
+			// The daemon rejects unknown states, so only valid ones are sent.
+			if state := strings.ToLower(c.Value); slices.Contains(containerStates, state) {
+				args.Add("status", state)
+			}
+		case c.Op == OpEqual && c.Type == FilterName && resource != ResourceImages:
+			// The daemon matches names as unanchored regexes.
+			args.Add("name", "(?i)^"+regexp.QuoteMeta(c.Value)+"$")
+		case c.Op == OpEqual && (c.Type == FilterNetwork || c.Type == FilterVolume):
+			args.Add(string(c.Type), c.Value)
+		case c.Type == FilterDangling && !args.Contains("dangling"):
+			args.Add("dangling", strconv.FormatBool(c.Bool == (c.Op == OpEqual)))
+		case c.Type == FilterUsed && !args.Contains("dangling"):
+			args.Add("dangling", strconv.FormatBool(c.Bool != (c.Op == OpEqual)))
+		}
+	}
+	return args
+}
+
+// ContainerQuery returns the query that lists the containers the filter
+// can match: ServerFilters for the daemon, and a Keep that drops
+// containers as soon as the fields known at a stage rule them out, so only
+// the survivors are inspected and asked for stats.
+func (f *Filter) ContainerQuery() docker.ContainerQuery {
+	q := docker.ContainerQuery{Filters: f.ServerFilters(ResourceContainers)}
+	if f.IsEmpty() {
+		return q
+	}
+	q.Keep = func(c docker.ContainerInfo, stage docker.ContainerStage) bool {
+		if f.Fuzzy != "" {
+			return f.MatchContainer(c)
+		}
+		m := matcher{
+			search:    func(term string) bool { return matchContainerSearch(c, term) },
+			criterion: func(criterion Criterion) bool { return matchContainerCriterion(c, criterion) },
+		}
+		known := func(criterion Criterion) bool { return containerFieldKnown(criterion.Type, stage) }
+		return evalPartial(f.root(), m, known) != matchNo
+	}
+	return q
+}
+
+// Narrows reports whether rows of resource loaded under f can leave out
+// rows that exist: the daemon filters them, or for containers Keep drops
+// them.
+func (f *Filter) Narrows(resource Resource) bool {
+	if resource == ResourceContainers {
+		return !f.IsEmpty()
+	}
+	return f.ServerFilters(resource).Len() > 0
+}
+
+// Covers reports whether the rows of resource loaded under f include every
+// row g matches, so g can be evaluated on them without listing again. That
+// holds when whatever narrowed the listing under f is also required by g.
+func (f *Filter) Covers(g *Filter, resource Resource) bool {
+	if !f.Narrows(resource) {
+		return true
+	}
+	if f.Fuzzy != "" {
+		// Every term must match on its own, so more terms match fewer rows.
+		terms := strings.Fields(g.Fuzzy)
+		for _, term := range strings.Fields(f.Fuzzy) {
+			if !slices.Contains(terms, term) {
+				return false
+			}
+		}
+		return true
+	}
+
+	required := make(map[string]bool)
+	for _, n := range conjuncts(g) {
+		required[n.String()] = true
+	}
+	for _, n := range conjuncts(f) {
+		if resource != ResourceContainers {
+			// Only what ServerFilters sent narrowed the listing.
+			c, ok := n.(*CriterionNode)
+			if !ok || (&Filter{Criteria: []Criterion{c.Criterion}}).ServerFilters(resource).Len() == 0 {
+				continue
+			}
+		}
+		if !required[n.String()] {
+			return false
+		}
+	}
+	return true
+}
+
+// conjuncts returns the parts of a filter joined by and, which every
+// matching row satisfies. A fuzzy filter has none.
+func conjuncts(f *Filter) []Node {
+	var parts []Node
+	var walk func(n Node)
+	walk = func(n Node) {
+		if and, ok := n.(*AndNode); ok {
+			for _, child := range and.Children {
+				walk(child)
+			}
+			return
+		}
+		parts = append(parts, n)
+	}
+	if f.Fuzzy == "" {
+		walk(f.root())
+	}
+	return parts
+}
+
+// containerFieldKnown reports whether a container field is filled in at a
+// listing stage.
+func containerFieldKnown(t FilterType, stage docker.ContainerStage) bool {
+	switch t {
+	case FilterExitCode, FilterRestarts, FilterOOM, FilterStarted, FilterFinished:
+		return stage >= docker.StageInspected
+	case FilterInspect, FilterCrashLoop, FilterCPU, FilterMem, FilterMemory, FilterNetRx, FilterNetTx, FilterNetIO,
+		FilterBlockRead, FilterBlockWrite, FilterBlockIO, FilterPIDs:
+		return false
+	default:
+		return true
+	}
+}
+
+// requiredCriteria returns the criteria joined to the rest of the filter by
+// and, which every matching row satisfies.
+func (f *Filter) requiredCriteria() []Criterion {
+	var required []Criterion
+	for _, n := range conjuncts(f) {
+		if c, ok := n.(*CriterionNode); ok {
+			required = append(required, c.Criterion)
+		}
+	}
+	return required
+}
+
+// root returns the filter as an expression, building one for filters made
+// from Criteria and SearchTerm directly.
+func (f *Filter) root() Node {
+	if f.Expr != nil {
+		return f.Expr
+	}
+	and := &AndNode{}
+	if f.SearchTerm != "" {
+		and.Children = append(and.Children, &SearchNode{Term: f.SearchTerm})
+	}
+	for _, c := range f.Criteria {
+		and.Children = append(and.Children, &CriterionNode{Criterion: c})
+	}
+	return and
+}
+
+// partialMatch is the result of evaluating an expression when some fields
+// are not known yet.
+type partialMatch int
+
+const (
+	matchNo partialMatch = iota
+	matchYes
+	matchUnknown
+)
+
+// evalPartial evaluates n with three-valued logic: criteria on fields that
+// known rejects are unknown, and so is any expression whose result depends
+// on them.
+func evalPartial(n Node, m matcher, known func(Criterion) bool) partialMatch {
+	switch n := n.(type) {
+	case *AndNode:
+		result := matchYes
+		for _, child := range n.Children {
+			switch evalPartial(child, m, known) {
+			case matchNo:
+				return matchNo
+			case matchUnknown:
+				result = matchUnknown
+			}
+		}
+		return result
+	case *OrNode:
+		result := matchNo
+		for _, child := range n.Children {
+			switch evalPartial(child, m, known) {
+			case matchYes:
+				return matchYes
+			case matchUnknown:
+				result = matchUnknown
+			}
+		}
+		return result
+	case *NotNode:
+		switch evalPartial(n.Child, m, known) {
+		case matchYes:
+			return matchNo
+		case matchNo:
+			return matchYes
+		}
+		return matchUnknown
+	case *CriterionNode:
+		if !known(n.Criterion) {
+			return matchUnknown
+		}
+		return partialFromBool(m.criterion(n.Criterion))
+	case *SearchNode:
+		return partialFromBool(m.search(n.Term))
+	}
+	return matchUnknown
+}
+
+func partialFromBool(b bool) partialMatch {
+	if b {
+		return matchYes
+	}
+	return matchNo
+}
@@ -0,0 +1,134 @@
+package filter
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"dock-it/internal/docker"
+)
+
+func TestServerFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   string
+		resource Resource
+		want     map[string][]string
+	}{
+		{"state", "state=Running", ResourceContainers, map[string][]string{"status": {"running"}}},
+		{"name is anchored and escaped", "name=web.1", ResourceContainers, map[string][]string{"name": {`(?i)^web\.1$`}}},
+		{"label presence", "label.tier=web, label.env", ResourceContainers, map[string][]string{"label": {"env", "tier"}}},
+		{"network and volume", "network=front, volume=data", ResourceContainers, map[string][]string{"network": {"front"}, "volume": {"data"}}},
+		{"in-memory criteria", "cpu>50, name~web, age>1h, image=nginx", ResourceContainers, map[string][]string{}},
+		{"only required criteria", "state=running or state=paused", ResourceContainers, map[string][]string{}},
+		{"negation", "not state=running, label.tier!=web", ResourceContainers, map[string][]string{}},
+		{"nested and", "(state=running and name=web) and redis", ResourceContainers, map[string][]string{"status": {"running"}, "name": {"(?i)^web$"}}},
+		{"dangling", "dangling=true", ResourceImages, map[string][]string{"dangling": {"true"}}},
+		{"not dangling", "dangling!=true", ResourceImages, map[string][]string{"dangling": {"false"}}},
+		{"image name is a tag", "name=nginx", ResourceImages, map[string][]string{}},
+		{"used volumes", "used=true", ResourceVolumes, map[string][]string{"dangling": {"false"}}},
+		{"network name", "name=bridge", ResourceNetworks, map[string][]string{"name": {"(?i)^bridge$"}}},
+		{"fields of another view", "dangling=true", ResourceContainers, map[string][]string{}},
+		{"fuzzy", "?web", ResourceContainers, map[string][]string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.filter, err)
+			}
+			args := f.ServerFilters(tt.resource)
+			got := make(map[string][]string)
+			for _, key := range args.Keys() {
+				values := args.Get(key)
+				sort.Strings(values)
+				got[key] = values
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ServerFilters(%q) = %v, want %v", tt.filter, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestContainerQueryKeep(t *testing.T) {
+	running := docker.ContainerInfo{Name: "web", State: "running", RestartCount: 5}
+	exited := docker.ContainerInfo{Name: "db", State: "exited", ExitCode: 1}
+
+	tests := []struct {
+		filter   string
+		info     docker.ContainerInfo
+		stage    docker.ContainerStage
+		wantKeep bool
+	}{
+		{"name=web", running, docker.StageListed, true},
+		{"name=web", exited, docker.StageListed, false},
+		{"cpu>50", exited, docker.StageListed, true},
+		{"cpu>50 and state=running", exited, docker.StageListed, false},
+		{"not cpu>50", exited, docker.StageInspected, true},
+		{"cpu>50 or name=db", running, docker.StageListed, true},
+		{"restarts>3", running, docker.StageListed, true},
+		{"restarts>3", running, docker.StageInspected, true},
+		{"restarts>3, cpu>50", exited, docker.StageInspected, false},
+		{"exitcode=0 or cpu>50", exited, docker.StageInspected, true},
+		{"not (name=db or crashloop=true)", exited, docker.StageInspected, false},
+		{"?wb", running, docker.StageListed, true},
+		{"?wb", exited, docker.StageListed, false},
+	}
+
+	for _, tt := range tests {
+		f, err := ParseFilterFor(tt.filter, ResourceContainers)
+		if err != nil {
+			t.Fatalf("ParseFilterFor(%q): %v", tt.filter, err)
+		}
+		q := f.ContainerQuery()
+		if got := q.Keep(tt.info, tt.stage); got != tt.wantKeep {
+			t.Errorf("Keep(%q, %s, stage %d) = %v, want %v", tt.filter, tt.info.Name, tt.stage, got, tt.wantKeep)
+		}
+	}
+
+	if q := New().ContainerQuery(); q.Keep != nil || q.Filters.Len() != 0 {
+		t.Errorf("empty filter query = %+v, want no filters and no Keep", q)
+	}
+}
+
+func TestCovers(t *testing.T) {
+	tests := []struct {
+		name     string
+		loaded   string
+		filter   string
+		resource Resource
+		want     bool
+	}{
+		{"unfiltered", "", "state=running", ResourceContainers, true},
+		{"same filter", "state=running", "state=running", ResourceContainers, true},
+		{"refinement", "state=running", "state=running, name~web", ResourceContainers, true},
+		{"nested refinement", "state=running, cpu>50", "(cpu>50 and state=running) and redis", ResourceContainers, true},
+		{"loosened", "state=running, name~web", "state=running", ResourceContainers, false},
+		{"replaced", "state=running", "state=exited", ResourceContainers, false},
+		{"or loosens", "state=running", "state=running or state=exited", ResourceContainers, false},
+		{"cleared", "name~web", "", ResourceContainers, false},
+		{"fuzzy refinement", "?web", "?web ngx", ResourceContainers, true},
+		{"fuzzy loosened", "?web ngx", "?web", ResourceContainers, false},
+		{"in-memory criteria do not narrow images", "size>1GB", "", ResourceImages, true},
+		{"pushed-down criterion kept", "dangling=true, size>1GB", "dangling=true", ResourceImages, true},
+		{"pushed-down criterion dropped", "dangling=true", "size>1GB", ResourceImages, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := ParseFilter(tt.loaded)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.loaded, err)
+			}
+			f, err := ParseFilter(tt.filter)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q): %v", tt.filter, err)
+			}
+			if got := loaded.Covers(f, tt.resource); got != tt.want {
+				t.Errorf("Covers(%q, %q) = %v, want %v", tt.loaded, tt.filter, got, tt.want)
+			}
+		})
+	}
+}
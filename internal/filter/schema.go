@@ -0,0 +1,284 @@
+package filter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Resource names a table view whose rows a filter is applied to.
+type Resource string
+
+const (
+	ResourceContainers Resource = "containers"
+	ResourceImages     Resource = "images"
+	ResourceNetworks   Resource = "networks"
+	ResourceVolumes    Resource = "volumes"
+)
+
+// ValueKind is the type of value a field compares against.
+type ValueKind int
+
+const (
+	ValueString ValueKind = iota
+	ValueDuration
+	ValueBytes
+	ValueNumber
+	ValuePercent
+	ValueBool
+	ValuePort
+	ValueLabel
+	ValueTime
+	ValueInspect
+)
+
+var (
+	stringOps  = []ComparisonOp{OpEqual, OpNotEqual, OpContains, OpNotContains, OpRegex}
+	numericOps = []ComparisonOp{OpEqual, OpNotEqual, OpGreater, OpLess, OpGreaterEqual, OpLessEqual}
+	boolOps    = []ComparisonOp{OpEqual, OpNotEqual}
+	allOps     = []ComparisonOp{OpEqual, OpNotEqual, OpGreater, OpLess, OpGreaterEqual, OpLessEqual, OpContains, OpNotContains, OpRegex}
+)
+
+// Ops returns the operators valid for the kind, in display order.
+func (k ValueKind) Ops() []ComparisonOp {
+	switch k {
+	case ValueString:
+		return stringOps
+	case ValueDuration, ValueTime, ValueBytes, ValueNumber, ValuePercent:
+		return numericOps
+	case ValueBool:
+		return boolOps
+	default:
+		return allOps
+	}
+}
+
+// Field describes a filterable field of a resource.
+type Field struct {
+	Type   FilterType
+	Kind   ValueKind
+	Values []string // fixed set of valid values for = and !=, if any
+}
+
+var (
+	containerStates = []string{"created", "running", "paused", "restarting", "removing", "exited", "dead"}
+	networkScopes   = []string{"local", "global", "swarm"}
+	boolValues      = []string{"true", "false"}
+)
+
+// schema lists the fields each view understands, in completion order.
+var schema = map[Resource][]Field{
+	ResourceContainers: {
+		{Type: FilterName, Kind: ValueString},
+		{Type: FilterState, Kind: ValueString, Values: containerStates},
+		{Type: FilterStatus, Kind: ValueString},
+		{Type: FilterAge, Kind: ValueDuration},
+		{Type: FilterCreated, Kind: ValueTime},
+		{Type: FilterStarted, Kind: ValueTime},
+		{Type: FilterFinished, Kind: ValueTime},
+		{Type: FilterExitCode, Kind: ValueNumber},
+		{Type: FilterRestarts, Kind: ValueNumber},
+		{Type: FilterOOM, Kind: ValueBool, Values: boolValues},
+		{Type: FilterCrashLoop, Kind: ValueBool, Values: boolValues},
+		{Type: FilterOutdated, Kind: ValueBool, Values: boolValues},
+		{Type: FilterCPU, Kind: ValuePercent},
+		{Type: FilterMem, Kind: ValuePercent},
+		{Type: FilterMemory, Kind: ValueBytes},
+		{Type: FilterNetRx, Kind: ValueBytes},
+		{Type: FilterNetTx, Kind: ValueBytes},
+		{Type: FilterNetIO, Kind: ValueBytes},
+		{Type: FilterBlockRead, Kind: ValueBytes},
+		{Type: FilterBlockWrite, Kind: ValueBytes},
+		{Type: FilterBlockIO, Kind: ValueBytes},
+		{Type: FilterPIDs, Kind: ValueNumber},
+		{Type: FilterPort, Kind: ValuePort},
+		{Type: FilterImage, Kind: ValueString},
+		{Type: FilterNetwork, Kind: ValueString},
+		{Type: FilterVolume, Kind: ValueString},
+		{Type: FilterLabel, Kind: ValueLabel},
+		{Type: FilterInspect, Kind: ValueInspect},
+	},
+	ResourceImages: {
+		{Type: FilterTag, Kind: ValueString},
+		{Type: FilterName, Kind: ValueString},
+		{Type: FilterAge, Kind: ValueDuration},
+		{Type: FilterCreated, Kind: ValueTime},
+		{Type: FilterSize, Kind: ValueBytes},
+		{Type: FilterDangling, Kind: ValueBool, Values: boolValues},
+		{Type: FilterLabel, Kind: ValueLabel},
+		{Type: FilterInspect, Kind: ValueInspect},
+	},
+	ResourceNetworks: {
+		{Type: FilterName, Kind: ValueString},
+		{Type: FilterDriver, Kind: ValueString},
+		{Type: FilterScope, Kind: ValueString, Values: networkScopes},
+		{Type: FilterAge, Kind: ValueDuration},
+		{Type: FilterCreated, Kind: ValueTime},
+		{Type: FilterContainers, Kind: ValueNumber},
+		{Type: FilterSubnet, Kind: ValueString},
+		{Type: FilterInternal, Kind: ValueBool, Values: boolValues},
+		{Type: FilterAttachable, Kind: ValueBool, Values: boolValues},
+		{Type: FilterLabel, Kind: ValueLabel},
+		{Type: FilterInspect, Kind: ValueInspect},
+	},
+	ResourceVolumes: {
+		{Type: FilterName, Kind: ValueString},
+		{Type: FilterDriver, Kind: ValueString},
+		{Type: FilterAge, Kind: ValueDuration},
+		{Type: FilterCreated, Kind: ValueTime},
+		{Type: FilterUsed, Kind: ValueBool, Values: boolValues},
+		{Type: FilterAnonymous, Kind: ValueBool, Values: boolValues},
+		{Type: FilterLabel, Kind: ValueLabel},
+		{Type: FilterInspect, Kind: ValueInspect},
+	},
+}
+
+// Fields returns the fields a view can be filtered on. An empty resource
+// returns the fields of every view.
+func Fields(resource Resource) []Field {
+	if resource != "" {
+		return schema[resource]
+	}
+	seen := make(map[FilterType]struct{})
+	var fields []Field
+	for _, r := range []Resource{ResourceContainers, ResourceImages, ResourceNetworks, ResourceVolumes} {
+		for _, f := range schema[r] {
+			if _, ok := seen[f.Type]; !ok {
+				seen[f.Type] = struct{}{}
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields
+}
+
+func lookupField(resource Resource, t FilterType) (Field, bool) {
+	for _, f := range Fields(resource) {
+		if f.Type == t {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// validator checks criteria against the fields of one view while parsing.
+type validator struct {
+	resource Resource
+}
+
+func (v validator) viewName() string {
+	if v.resource == "" {
+		return "any view"
+	}
+	return string(v.resource)
+}
+
+func (v validator) field(name string) (Field, error) {
+	t := fieldType(name)
+	if f, ok := lookupField(v.resource, t); ok {
+		return f, nil
+	}
+
+	names := make([]string, 0, len(Fields(v.resource)))
+	for _, f := range Fields(v.resource) {
+		if f.Type == FilterLabel {
+			names = append(names, "label.<key>")
+			continue
+		}
+		if f.Type == FilterInspect {
+			names = append(names, ".<path>")
+			continue
+		}
+		names = append(names, string(f.Type))
+	}
+	if suggestions := suggest(name, names); len(suggestions) > 0 {
+		return Field{}, fmt.Errorf("unknown field %q for %s (did you mean %s?)", name, v.viewName(), quoteList(suggestions))
+	}
+	return Field{}, fmt.Errorf("unknown field %q for %s (valid: %s)", name, v.viewName(), strings.Join(names, ", "))
+}
+
+func (v validator) op(f Field, op ComparisonOp) error {
+	for _, valid := range f.Kind.Ops() {
+		if valid == op {
+			return nil
+		}
+	}
+	ops := make([]string, len(f.Kind.Ops()))
+	for i, valid := range f.Kind.Ops() {
+		ops[i] = string(valid)
+	}
+	return fmt.Errorf("operator %s is not valid for %s (use %s)", op, f.Type, strings.Join(ops, " "))
+}
+
+func (v validator) value(f Field, c Criterion) error {
+	if len(f.Values) == 0 || (c.Op != OpEqual && c.Op != OpNotEqual) {
+		return nil
+	}
+	for _, valid := range f.Values {
+		if strings.EqualFold(valid, c.Value) {
+			return nil
+		}
+	}
+	if suggestions := suggest(strings.ToLower(c.Value), f.Values); len(suggestions) > 0 {
+		return fmt.Errorf("unknown %s %q (did you mean %s?)", f.Type, c.Value, quoteList(suggestions))
+	}
+	return fmt.Errorf("unknown %s %q (valid: %s)", f.Type, c.Value, strings.Join(f.Values, ", "))
+}
+
+// suggest returns the candidates within a small edit distance of word, or
+// starting with it, closest first.
+func suggest(word string, candidates []string) []string {
+	type scored struct {
+		name string
+		dist int
+	}
+	var matches []scored
+	maxDist := 2
+	if len(word) <= 3 {
+		maxDist = 1
+	}
+	for _, c := range candidates {
+		d := editDistance(word, c)
+		if d <= maxDist || (len(word) >= 2 && strings.HasPrefix(c, word)) {
+			matches = append(matches, scored{c, d})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool { return matches[i].dist < matches[j].dist })
+	if len(matches) > 3 {
+		matches = matches[:3]
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = m.name
+	}
+	return names
+}
+
+// editDistance is the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+func quoteList(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	return strings.Join(quoted, " or ")
+}
@@ -0,0 +1,216 @@
+package filter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// rangeSeparator joins the two ends of a range value such as age=1h..1d.
+const rangeSeparator = ".."
+
+var errMixedRange = errors.New("both ends of a range must be durations, or both times")
+
+// timeKeywords name the calendar days a time field can be compared with.
+var timeKeywords = []string{"today", "yesterday"}
+
+// timeLayouts are the absolute time formats accepted by time fields, with
+// how much time each names. A date covers the whole day.
+var timeLayouts = []struct {
+	layout string
+	span   time.Duration // 0 for a whole day
+}{
+	{time.RFC3339, time.Second},
+	{"2006-01-02T15:04:05", time.Second},
+	{"2006-01-02T15:04", time.Minute},
+	{"2006-01-02 15:04:05", time.Second},
+	{"2006-01-02 15:04", time.Minute},
+	{"2006-01-02", 0},
+}
+
+// parseTimeValue parses the value of a created, started or finished
+// criterion. An absolute time, a date or a day keyword sets From and To to
+// the span it names; a duration is an age, as for the age field. Either may
+// be a range lo..hi, which only = and != accept. A day keyword keeps naming
+// the current day: matchTime resolves it again, so From and To only record
+// the span it named at parse time.
+func parseTimeValue(c *Criterion, value string, now time.Time) error {
+	lo, hi, isRange := strings.Cut(value, rangeSeparator)
+	if !isRange {
+		if d, err := parseDuration(value); err == nil {
+			c.Duration, c.DurationMax = d, d
+			return nil
+		}
+		from, to, err := timeValueSpan(value, now)
+		if err != nil {
+			return err
+		}
+		c.From, c.To = from, to
+		return nil
+	}
+
+	if err := checkRangeOp(c.Op); err != nil {
+		return err
+	}
+	c.Range = true
+	if dLo, err := parseDuration(lo); err == nil {
+		dHi, err := parseDuration(hi)
+		if err != nil {
+			return errMixedRange
+		}
+		return setDurationRange(c, value, dLo, dHi)
+	}
+	if _, err := parseDuration(hi); err == nil {
+		return errMixedRange
+	}
+	from, to, err := timeValueSpan(value, now)
+	if err != nil {
+		return err
+	}
+	if !to.After(from) {
+		return fmt.Errorf("range %s ends before it starts", value)
+	}
+	c.From, c.To = from, to
+	return nil
+}
+
+// parseAgeValue parses the value of an age criterion: a duration or a range
+// of durations.
+func parseAgeValue(c *Criterion, value string) error {
+	lo, hi, isRange := strings.Cut(value, rangeSeparator)
+	if !isRange {
+		d, err := parseDuration(value)
+		if err != nil {
+			return err
+		}
+		c.Duration, c.DurationMax = d, d
+		return nil
+	}
+	if err := checkRangeOp(c.Op); err != nil {
+		return err
+	}
+	dLo, err := parseDuration(lo)
+	if err != nil {
+		return err
+	}
+	dHi, err := parseDuration(hi)
+	if err != nil {
+		return err
+	}
+	c.Range = true
+	return setDurationRange(c, value, dLo, dHi)
+}
+
+func checkRangeOp(op ComparisonOp) error {
+	if op != OpEqual && op != OpNotEqual {
+		return fmt.Errorf("ranges only work with = and !=")
+	}
+	return nil
+}
+
+func setDurationRange(c *Criterion, value string, lo, hi time.Duration) error {
+	if hi < lo {
+		return fmt.Errorf("range %s ends before it starts", value)
+	}
+	c.Duration, c.DurationMax = lo, hi
+	return nil
+}
+
+// timeValueSpan returns the span [from, to) named by an absolute time
+// value: a single day keyword, date or timestamp, or a range of them.
+func timeValueSpan(value string, now time.Time) (time.Time, time.Time, error) {
+	lo, hi, isRange := strings.Cut(value, rangeSeparator)
+	from, to, err := parseTimeSpan(lo, now)
+	if err != nil || !isRange {
+		return from, to, err
+	}
+	_, to, err = parseTimeSpan(hi, now)
+	return from, to, err
+}
+
+// namesDay reports whether a time value or either end of a range is a day
+// keyword, whose span depends on when it is evaluated.
+func namesDay(value string) bool {
+	lo, hi, _ := strings.Cut(value, rangeSeparator)
+	for _, keyword := range timeKeywords {
+		if strings.EqualFold(lo, keyword) || strings.EqualFold(hi, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTimeSpan returns the span [from, to) named by a day keyword, a date
+// or a timestamp, in local time unless the timestamp has a zone.
+func parseTimeSpan(value string, now time.Time) (time.Time, time.Time, error) {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch strings.ToLower(value) {
+	case "today":
+		return midnight, midnight.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return midnight.AddDate(0, 0, -1), midnight, nil
+	}
+
+	for _, l := range timeLayouts {
+		t, err := time.ParseInLocation(l.layout, value, now.Location())
+		if err != nil {
+			continue
+		}
+		if l.span == 0 {
+			return t, t.AddDate(0, 0, 1), nil
+		}
+		return t, t.Add(l.span), nil
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("%q is not a duration, date (2006-01-02), time (2006-01-02T15:04) or %s", value, strings.Join(timeKeywords, "/"))
+}
+
+// compareDuration compares an age with a duration criterion. A range
+// includes both ends.
+func compareDuration(actual time.Duration, c Criterion) bool {
+	if c.Range {
+		in := actual >= c.Duration && actual <= c.DurationMax
+		return in == (c.Op == OpEqual)
+	}
+	return compareNumeric(float64(actual), c.Op, float64(c.Duration))
+}
+
+// matchTime compares when something happened with a time criterion.
+// Absolute values compare against the span they name: = means within it,
+// > after it and < before it. Durations compare how long ago it happened,
+// like age. Day keywords name the day the match runs on. A zero time (a
+// container that never started or finished) never matches, whatever the
+// operator.
+func matchTime(t time.Time, c Criterion) bool {
+	return matchTimeAt(t, c, time.Now())
+}
+
+func matchTimeAt(t time.Time, c Criterion, now time.Time) bool {
+	if t.IsZero() {
+		return false
+	}
+	if c.From.IsZero() {
+		return compareDuration(now.Sub(t), c)
+	}
+	from, to := c.From, c.To
+	if namesDay(c.Value) {
+		if dayFrom, dayTo, err := timeValueSpan(c.Value, now); err == nil {
+			from, to = dayFrom, dayTo
+		}
+	}
+	switch c.Op {
+	case OpEqual:
+		return !t.Before(from) && t.Before(to)
+	case OpNotEqual:
+		return t.Before(from) || !t.Before(to)
+	case OpGreater:
+		return !t.Before(to)
+	case OpGreaterEqual:
+		return !t.Before(from)
+	case OpLess:
+		return t.Before(from)
+	case OpLessEqual:
+		return t.Before(to)
+	}
+	return false
+}
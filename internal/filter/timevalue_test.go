@@ -0,0 +1,138 @@
+package filter
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"dock-it/internal/docker"
+)
+
+func TestParseTimeSpan(t *testing.T) {
+	now := time.Date(2025, 11, 20, 15, 30, 0, 0, time.UTC)
+	day := func(d int) time.Time { return time.Date(2025, 11, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		value    string
+		from, to time.Time
+	}{
+		{"today", day(20), day(21)},
+		{"Yesterday", day(19), day(20)},
+		{"2025-11-01", day(1), day(2)},
+		{"2025-12-01T10:00", time.Date(2025, 12, 1, 10, 0, 0, 0, time.UTC), time.Date(2025, 12, 1, 10, 1, 0, 0, time.UTC)},
+		{"2025-12-01T10:00:30", time.Date(2025, 12, 1, 10, 0, 30, 0, time.UTC), time.Date(2025, 12, 1, 10, 0, 31, 0, time.UTC)},
+		{"2025-12-01T10:00:00+02:00", time.Date(2025, 12, 1, 8, 0, 0, 0, time.UTC), time.Date(2025, 12, 1, 8, 0, 1, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		from, to, err := parseTimeSpan(tt.value, now)
+		if err != nil {
+			t.Errorf("parseTimeSpan(%q): %v", tt.value, err)
+			continue
+		}
+		if !from.Equal(tt.from) || !to.Equal(tt.to) {
+			t.Errorf("parseTimeSpan(%q) = [%v, %v), want [%v, %v)", tt.value, from, to, tt.from, tt.to)
+		}
+	}
+
+	if _, _, err := parseTimeSpan("2025-13-01", now); err == nil {
+		t.Error("parseTimeSpan(\"2025-13-01\") succeeded, want error")
+	}
+}
+
+func TestTimeCriteria(t *testing.T) {
+	now := time.Now()
+	c := docker.ContainerInfo{
+		Created:    now.Add(-48 * time.Hour),
+		StartedAt:  now.Add(-2 * time.Hour),
+		FinishedAt: now.Add(-5 * time.Minute),
+	}
+	neverStarted := docker.ContainerInfo{Created: now.Add(-time.Hour)}
+	yesterday := now.AddDate(0, 0, -1).Format("2006-01-02")
+	tomorrow := now.AddDate(0, 0, 1).Format("2006-01-02")
+
+	tests := []struct {
+		filter string
+		info   docker.ContainerInfo
+		want   bool
+	}{
+		{"finished<10m", c, true},
+		{"finished>10m", c, false},
+		{"started=1h..3h", c, true},
+		{"started!=1h..3h", c, false},
+		{"age=1d..3d", c, true},
+		{"age=1h..1d", c, false},
+		{"age!=1h..1d", c, true},
+		{"created<" + yesterday, c, true},
+		{"created>" + yesterday, c, false},
+		{"finished=today", c, c.FinishedAt.Day() == now.Day()},
+		{"finished=yesterday", c, c.FinishedAt.Day() != now.Day()},
+		{"finished<" + tomorrow, c, true},
+		{"started>2000-01-01", neverStarted, false},
+		{"not started>2000-01-01", neverStarted, true},
+		{"finished<10m", neverStarted, false},
+	}
+	for _, tt := range tests {
+		f, err := ParseFilterFor(tt.filter, ResourceContainers)
+		if err != nil {
+			t.Fatalf("ParseFilterFor(%q): %v", tt.filter, err)
+		}
+		if got := f.MatchContainer(tt.info); got != tt.want {
+			t.Errorf("MatchContainer(%q) = %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestDayKeywordsFollowTheClock(t *testing.T) {
+	parsedAt := time.Date(2025, 11, 20, 15, 30, 0, 0, time.UTC)
+	later := parsedAt.AddDate(0, 0, 3)
+
+	tests := []struct {
+		value string
+		t     time.Time
+		want  bool
+	}{
+		{"today", later, true},
+		{"today", parsedAt, false},
+		{"yesterday", later.AddDate(0, 0, -1), true},
+		{"yesterday", parsedAt.AddDate(0, 0, -1), false},
+		{"yesterday..today", later.AddDate(0, 0, -1), true},
+		{"2025-11-20", parsedAt, true},
+	}
+	for _, tt := range tests {
+		c := Criterion{Type: FilterCreated, Op: OpEqual, Value: tt.value}
+		if err := parseTimeValue(&c, tt.value, parsedAt); err != nil {
+			t.Fatalf("parseTimeValue(%q): %v", tt.value, err)
+		}
+		if got := matchTimeAt(tt.t, c, later); got != tt.want {
+			t.Errorf("created=%s matched %v three days later = %v, want %v", tt.value, tt.t, got, tt.want)
+		}
+		if c.String() != "created="+tt.value {
+			t.Errorf("String() = %q, want the keyword kept", c.String())
+		}
+	}
+}
+
+func TestTimeCriteriaErrors(t *testing.T) {
+	tests := []struct {
+		filter string
+		want   string
+	}{
+		{"age>1h..1d", "ranges only work with = and !="},
+		{"age=1d..1h", "range 1d..1h ends before it starts"},
+		{"created=today..1h", "both ends of a range must be durations, or both times"},
+		{"created=1h..today", "both ends of a range must be durations, or both times"},
+		{"created=2025-11-02..2025-11-01", "ends before it starts"},
+		{"created=last-week", `"last-week" is not a duration, date`},
+		{"created~today", "operator ~ is not valid for created"},
+	}
+	for _, tt := range tests {
+		_, err := ParseFilterFor(tt.filter, ResourceContainers)
+		if err == nil {
+			t.Errorf("ParseFilterFor(%q) succeeded, want error", tt.filter)
+			continue
+		}
+		if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseFilterFor(%q) error = %q, want it to contain %q", tt.filter, err, tt.want)
+		}
+	}
+}
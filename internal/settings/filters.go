@@ -0,0 +1,157 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const filtersFile = "filters.json"
+
+// MaxFilterHistory caps the filter inputs remembered per view.
+const MaxFilterHistory = 50
+
+// PresetKeys are the keys a saved filter can be bound to.
+var PresetKeys = []string{"F1", "F2", "F3", "F4", "F5", "F6", "F7", "F8", "F9", "F10", "F11", "F12"}
+
+// SavedFilter is a named filter expression for one view.
+type SavedFilter struct {
+	Name  string `json:"name"`
+	View  string `json:"view"`
+	Query string `json:"query"`
+	// Key is the preset key the filter is bound to, such as "F1", or empty.
+	Key string `json:"key,omitempty"`
+}
+
+// FilterLibrary holds the saved filters and the per-view history of filter
+// inputs, oldest first.
+type FilterLibrary struct {
+	Saved   []SavedFilter       `json:"saved,omitempty"`
+	History map[string][]string `json:"history,omitempty"`
+}
+
+// ForView returns the filters saved for view sorted by name.
+func (l FilterLibrary) ForView(view string) []SavedFilter {
+	var out []SavedFilter
+	for _, f := range l.Saved {
+		if f.View == view {
+			out = append(out, f)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Name < out[j].Name })
+	return out
+}
+
+// Preset returns the filter of view bound to key.
+func (l FilterLibrary) Preset(view, key string) (SavedFilter, bool) {
+	for _, f := range l.Saved {
+		if f.View == view && f.Key == key {
+			return f, true
+		}
+	}
+	return SavedFilter{}, false
+}
+
+// LoadFilterLibrary returns the saved filters and filter history.
+func LoadFilterLibrary() (FilterLibrary, error) {
+	var lib FilterLibrary
+	if err := loadJSON(filtersFile, &lib); err != nil {
+		return FilterLibrary{}, err
+	}
+	if lib.History == nil {
+		lib.History = make(map[string][]string)
+	}
+	return lib, nil
+}
+
+// SaveFilter stores f, replacing any filter of the same view with the same
+// name. Binding f to a key unbinds whichever filter of the view held it.
+func SaveFilter(f SavedFilter) error {
+	f.Name = strings.TrimSpace(f.Name)
+	f.Query = strings.TrimSpace(f.Query)
+	f.Key = strings.ToUpper(strings.TrimSpace(f.Key))
+	if f.Name == "" {
+		return errors.New("filter name is required")
+	}
+	if f.Query == "" {
+		return errors.New("filter query is required")
+	}
+	if f.Key != "" && !isPresetKey(f.Key) {
+		return fmt.Errorf("unknown preset key %q (want F1-F12)", f.Key)
+	}
+
+	lib, err := LoadFilterLibrary()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range lib.Saved {
+		s := &lib.Saved[i]
+		if s.View != f.View {
+			continue
+		}
+		if s.Name == f.Name {
+			*s = f
+			replaced = true
+		} else if f.Key != "" && s.Key == f.Key {
+			s.Key = ""
+		}
+	}
+	if !replaced {
+		lib.Saved = append(lib.Saved, f)
+	}
+	return saveJSON(filtersFile, lib)
+}
+
+// DeleteFilter removes the named filter of view if present.
+func DeleteFilter(view, name string) error {
+	lib, err := LoadFilterLibrary()
+	if err != nil {
+		return err
+	}
+	kept := lib.Saved[:0]
+	for _, f := range lib.Saved {
+		if f.View != view || f.Name != name {
+			kept = append(kept, f)
+		}
+	}
+	lib.Saved = kept
+	return saveJSON(filtersFile, lib)
+}
+
+// AddFilterHistory records query as the latest filter input of view. An
+// earlier copy of the same input is dropped, and only the newest
+// MaxFilterHistory inputs are kept.
+func AddFilterHistory(view, query string) error {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil
+	}
+
+	lib, err := LoadFilterLibrary()
+	if err != nil {
+		return err
+	}
+	var history []string
+	for _, h := range lib.History[view] {
+		if h != query {
+			history = append(history, h)
+		}
+	}
+	history = append(history, query)
+	if len(history) > MaxFilterHistory {
+		history = history[len(history)-MaxFilterHistory:]
+	}
+	lib.History[view] = history
+	return saveJSON(filtersFile, lib)
+}
+
+func isPresetKey(key string) bool {
+	for _, k := range PresetKeys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
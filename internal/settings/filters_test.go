@@ -0,0 +1,102 @@
+package settings
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSavedFilters(t *testing.T) {
+	t.Setenv(DirEnv, t.TempDir())
+
+	lib, err := LoadFilterLibrary()
+	if err != nil || len(lib.Saved) != 0 {
+		t.Fatalf("LoadFilterLibrary() on empty dir = %v, %v", lib, err)
+	}
+
+	saves := []SavedFilter{
+		{Name: " running ", View: "containers", Query: "state=running", Key: "f1"},
+		{Name: "busy", View: "containers", Query: "cpu>50", Key: "F1"},
+		{Name: "big", View: "images", Query: "size>1GB", Key: "F1"},
+		{Name: "running", View: "containers", Query: "state=running and not name~test"},
+	}
+	for _, f := range saves {
+		if err := SaveFilter(f); err != nil {
+			t.Fatalf("SaveFilter(%v) error = %v", f, err)
+		}
+	}
+
+	lib, err = LoadFilterLibrary()
+	if err != nil {
+		t.Fatalf("LoadFilterLibrary() error = %v", err)
+	}
+	want := []SavedFilter{
+		{Name: "busy", View: "containers", Query: "cpu>50", Key: "F1"},
+		{Name: "running", View: "containers", Query: "state=running and not name~test"},
+	}
+	if got := lib.ForView("containers"); !reflect.DeepEqual(got, want) {
+		t.Fatalf("ForView(containers) = %v, want %v", got, want)
+	}
+	if f, ok := lib.Preset("images", "F1"); !ok || f.Name != "big" {
+		t.Fatalf("Preset(images, F1) = %v, %v", f, ok)
+	}
+	if _, ok := lib.Preset("volumes", "F1"); ok {
+		t.Fatalf("Preset(volumes, F1) found a filter")
+	}
+
+	if err := DeleteFilter("containers", "busy"); err != nil {
+		t.Fatalf("DeleteFilter() error = %v", err)
+	}
+	lib, err = LoadFilterLibrary()
+	if err != nil {
+		t.Fatalf("LoadFilterLibrary() error = %v", err)
+	}
+	if got := len(lib.ForView("containers")); got != 1 {
+		t.Fatalf("containers filters after delete = %d, want 1", got)
+	}
+	if got := len(lib.ForView("images")); got != 1 {
+		t.Fatalf("images filters after delete = %d, want 1", got)
+	}
+}
+
+func TestSaveFilterValidation(t *testing.T) {
+	t.Setenv(DirEnv, t.TempDir())
+
+	bad := []SavedFilter{
+		{Name: " ", View: "containers", Query: "state=running"},
+		{Name: "empty", View: "containers", Query: " "},
+		{Name: "key", View: "containers", Query: "state=running", Key: "F13"},
+	}
+	for _, f := range bad {
+		if err := SaveFilter(f); err == nil {
+			t.Errorf("SaveFilter(%v) error = nil", f)
+		}
+	}
+}
+
+func TestFilterHistory(t *testing.T) {
+	t.Setenv(DirEnv, t.TempDir())
+
+	for _, q := range []string{"redis", " ", "state=running", "redis"} {
+		if err := AddFilterHistory("containers", q); err != nil {
+			t.Fatalf("AddFilterHistory(%q) error = %v", q, err)
+		}
+	}
+	for i := 0; i < MaxFilterHistory+5; i++ {
+		if err := AddFilterHistory("images", fmt.Sprintf("size>%dMB", i)); err != nil {
+			t.Fatalf("AddFilterHistory() error = %v", err)
+		}
+	}
+
+	lib, err := LoadFilterLibrary()
+	if err != nil {
+		t.Fatalf("LoadFilterLibrary() error = %v", err)
+	}
+	if got := lib.History["containers"]; !reflect.DeepEqual(got, []string{"state=running", "redis"}) {
+		t.Fatalf("containers history = %v", got)
+	}
+	images := lib.History["images"]
+	if len(images) != MaxFilterHistory || images[0] != "size>5MB" || images[len(images)-1] != fmt.Sprintf("size>%dMB", MaxFilterHistory+4) {
+		t.Fatalf("images history = %v", images)
+	}
+}
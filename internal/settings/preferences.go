@@ -0,0 +1,53 @@
+package settings
+
+import (
+	"fmt"
+	"time"
+)
+
+const preferencesFile = "preferences.json"
+
+// Preferences holds application-wide settings. The file is edited by hand;
+// missing fields keep their defaults.
+type Preferences struct {
+	CrashLoop CrashLoopSettings `json:"crashLoop"`
+}
+
+// CrashLoopSettings configures when a container is flagged as crash-looping:
+// after more than Restarts restarts within Window.
+type CrashLoopSettings struct {
+	// Restarts is the restart threshold; 0 keeps the default.
+	Restarts int `json:"restarts,omitempty"`
+	// Window is a duration such as "5m"; empty keeps the default.
+	Window string `json:"window,omitempty"`
+}
+
+// WindowDuration returns the parsed Window, or 0 when it is empty.
+func (s CrashLoopSettings) WindowDuration() (time.Duration, error) {
+	if s.Window == "" {
+		return 0, nil
+	}
+	d, err := time.ParseDuration(s.Window)
+	if err != nil {
+		return 0, fmt.Errorf("crash loop window: %w", err)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("crash loop window %q is negative", s.Window)
+	}
+	return d, nil
+}
+
+// LoadPreferences returns the saved preferences.
+func LoadPreferences() (Preferences, error) {
+	var p Preferences
+	if err := loadJSON(preferencesFile, &p); err != nil {
+		return Preferences{}, err
+	}
+	if p.CrashLoop.Restarts < 0 {
+		return Preferences{}, fmt.Errorf("crash loop restarts %d is negative", p.CrashLoop.Restarts)
+	}
+	if _, err := p.CrashLoop.WindowDuration(); err != nil {
+		return Preferences{}, err
+	}
+	return p, nil
+}
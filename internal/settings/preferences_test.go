@@ -0,0 +1,48 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLoadPreferences(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+
+	p, err := LoadPreferences()
+	if err != nil || p != (Preferences{}) {
+		t.Fatalf("LoadPreferences() on empty dir = %+v, %v", p, err)
+	}
+
+	write := func(content string) {
+		t.Helper()
+		if err := os.WriteFile(filepath.Join(dir, preferencesFile), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(`{"crashLoop": {"restarts": 5, "window": "10m"}}`)
+	p, err = LoadPreferences()
+	if err != nil {
+		t.Fatalf("LoadPreferences() error = %v", err)
+	}
+	if p.CrashLoop.Restarts != 5 {
+		t.Fatalf("restarts = %d, want 5", p.CrashLoop.Restarts)
+	}
+	if d, _ := p.CrashLoop.WindowDuration(); d != 10*time.Minute {
+		t.Fatalf("window = %v, want 10m", d)
+	}
+
+	for _, bad := range []string{
+		`{"crashLoop": {"window": "soon"}}`,
+		`{"crashLoop": {"window": "-1m"}}`,
+		`{"crashLoop": {"restarts": -1}}`,
+	} {
+		write(bad)
+		if _, err := LoadPreferences(); err == nil {
+			t.Errorf("LoadPreferences(%s) expected error", bad)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"dock-it/internal/docker"
+)
+
+const templatesFile = "templates.json"
+
+// RunTemplate is a named, reusable run-container configuration.
+type RunTemplate struct {
+	Name string         `json:"name"`
+	Spec docker.RunSpec `json:"spec"`
+}
+
+// LoadRunTemplates returns the saved templates sorted by name.
+func LoadRunTemplates() ([]RunTemplate, error) {
+	var templates []RunTemplate
+	if err := loadJSON(templatesFile, &templates); err != nil {
+		return nil, err
+	}
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// SaveRunTemplate stores t, replacing any template with the same name.
+func SaveRunTemplate(t RunTemplate) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return errors.New("template name is required")
+	}
+
+	templates, err := LoadRunTemplates()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i := range templates {
+		if templates[i].Name == t.Name {
+			templates[i] = t
+			replaced = true
+		}
+	}
+	if !replaced {
+		templates = append(templates, t)
+	}
+	return saveJSON(templatesFile, templates)
+}
+
+// DeleteRunTemplate removes the named template if present.
+func DeleteRunTemplate(name string) error {
+	templates, err := LoadRunTemplates()
+	if err != nil {
+		return err
+	}
+	kept := templates[:0]
+	for _, t := range templates {
+		if t.Name != name {
+			kept = append(kept, t)
+		}
+	}
+	return saveJSON(templatesFile, kept)
+}
@@ -0,0 +1,61 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"dock-it/internal/docker"
+)
+
+func TestRunTemplates(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+
+	templates, err := LoadRunTemplates()
+	if err != nil || len(templates) != 0 {
+		t.Fatalf("LoadRunTemplates() on empty dir = %v, %v", templates, err)
+	}
+
+	if err := SaveRunTemplate(RunTemplate{Name: "web", Spec: docker.RunSpec{Image: "nginx"}}); err != nil {
+		t.Fatalf("SaveRunTemplate() error = %v", err)
+	}
+	if err := SaveRunTemplate(RunTemplate{Name: "cache", Spec: docker.RunSpec{Image: "redis"}}); err != nil {
+		t.Fatalf("SaveRunTemplate() error = %v", err)
+	}
+	if err := SaveRunTemplate(RunTemplate{Name: "web", Spec: docker.RunSpec{Image: "nginx:1.27"}}); err != nil {
+		t.Fatalf("SaveRunTemplate() replace error = %v", err)
+	}
+
+	templates, err = LoadRunTemplates()
+	if err != nil {
+		t.Fatalf("LoadRunTemplates() error = %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "cache" || templates[1].Spec.Image != "nginx:1.27" {
+		t.Fatalf("LoadRunTemplates() = %+v", templates)
+	}
+
+	if err := DeleteRunTemplate("cache"); err != nil {
+		t.Fatalf("DeleteRunTemplate() error = %v", err)
+	}
+	templates, _ = LoadRunTemplates()
+	if len(templates) != 1 || templates[0].Name != "web" {
+		t.Fatalf("after delete = %+v", templates)
+	}
+
+	if err := SaveRunTemplate(RunTemplate{Name: "  "}); err == nil {
+		t.Fatalf("expected error for empty template name")
+	}
+}
+
+func TestLoadRunTemplatesInvalidJSON(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(DirEnv, dir)
+
+	if err := os.WriteFile(filepath.Join(dir, templatesFile), []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRunTemplates(); err == nil {
+		t.Fatalf("expected parse error")
+	}
+}
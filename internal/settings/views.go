@@ -0,0 +1,118 @@
+package settings
+
+import (
+	"strings"
+)
+
+const viewsFile = "views.json"
+
+// ViewSettings holds per-view table preferences, keyed by view name
+// ("containers", "images", "networks", "volumes").
+type ViewSettings struct {
+	// PinnedLabels are label keys shown as extra table columns.
+	PinnedLabels []string `json:"pinnedLabels,omitempty"`
+	// Sort is the table's sort order, most significant key first.
+	Sort []SortKey `json:"sort,omitempty"`
+	// Columns is the table's column layout in display order. Columns it does
+	// not list keep their defaults and follow the listed ones.
+	Columns []ColumnSettings `json:"columns,omitempty"`
+}
+
+// Column alignments.
+const (
+	AlignLeft   = "left"
+	AlignCenter = "center"
+	AlignRight  = "right"
+)
+
+// ColumnSettings is the layout of one table column, named by its header.
+type ColumnSettings struct {
+	Name   string `json:"name"`
+	Hidden bool   `json:"hidden,omitempty"`
+	// Width caps the column's width in cells; 0 leaves it unlimited.
+	Width int `json:"width,omitempty"`
+	// Align is AlignLeft, AlignCenter or AlignRight; empty keeps the
+	// column's default.
+	Align string `json:"align,omitempty"`
+}
+
+// SortKey is one column of a table's sort order. Column is the column's
+// header, or "label.<key>" for a pinned label column.
+type SortKey struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// LoadViewSettings returns the saved settings of every view.
+func LoadViewSettings() (map[string]ViewSettings, error) {
+	views := make(map[string]ViewSettings)
+	if err := loadJSON(viewsFile, &views); err != nil {
+		return nil, err
+	}
+	return views, nil
+}
+
+// SaveViewSettings stores the settings of a single view, keeping the others.
+func SaveViewSettings(view string, vs ViewSettings) error {
+	views, err := LoadViewSettings()
+	if err != nil {
+		return err
+	}
+	vs.PinnedLabels = cleanKeys(vs.PinnedLabels)
+	vs.Sort = cleanSort(vs.Sort)
+	vs.Columns = cleanColumns(vs.Columns)
+	views[view] = vs
+	return saveJSON(viewsFile, views)
+}
+
+// cleanSort drops keys without a column and later keys on a column that
+// is already sorted on.
+func cleanSort(keys []SortKey) []SortKey {
+	var out []SortKey
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		if _, ok := seen[key.Column]; ok || key.Column == "" {
+			continue
+		}
+		seen[key.Column] = struct{}{}
+		out = append(out, key)
+	}
+	return out
+}
+
+// cleanColumns drops columns without a name and later entries for a column
+// that is already listed, and resets invalid widths and alignments.
+func cleanColumns(columns []ColumnSettings) []ColumnSettings {
+	var out []ColumnSettings
+	seen := make(map[string]struct{}, len(columns))
+	for _, c := range columns {
+		c.Name = strings.TrimSpace(c.Name)
+		if _, ok := seen[c.Name]; ok || c.Name == "" {
+			continue
+		}
+		seen[c.Name] = struct{}{}
+		c.Width = max(c.Width, 0)
+		c.Align = strings.ToLower(c.Align)
+		if c.Align != AlignLeft && c.Align != AlignCenter && c.Align != AlignRight {
+			c.Align = ""
+		}
+		out = append(out, c)
+	}
+	return out
+}
+
+// cleanKeys trims keys and drops empty entries and duplicates, keeping the
+// original order.
+func cleanKeys(keys []string) []string {
+	var out []string
+	seen := make(map[string]struct{}, len(keys))
+	for _, key := range keys {
+		key = strings.TrimSpace(key)
+		if _, ok := seen[key]; ok || key == "" {
+			continue
+		}
+		seen[key] = struct{}{}
+		out = append(out, key)
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package settings
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestViewSettings(t *testing.T) {
+	t.Setenv(DirEnv, t.TempDir())
+
+	views, err := LoadViewSettings()
+	if err != nil || len(views) != 0 {
+		t.Fatalf("LoadViewSettings() on empty dir = %v, %v", views, err)
+	}
+
+	if err := SaveViewSettings("containers", ViewSettings{PinnedLabels: []string{" com.example.team ", "", "env", "env"}}); err != nil {
+		t.Fatalf("SaveViewSettings() error = %v", err)
+	}
+	sort := []SortKey{{Column: "CPU", Desc: true}, {Column: ""}, {Column: "NAME"}, {Column: "CPU"}}
+	columns := []ColumnSettings{{Name: "AGE", Hidden: true}, {Name: " NAME ", Width: -3, Align: "Right"}, {Name: "AGE"}, {Name: "DRIVER", Align: "middle"}}
+	if err := SaveViewSettings("volumes", ViewSettings{PinnedLabels: []string{"backup"}, Sort: sort, Columns: columns}); err != nil {
+		t.Fatalf("SaveViewSettings() error = %v", err)
+	}
+
+	views, err = LoadViewSettings()
+	if err != nil {
+		t.Fatalf("LoadViewSettings() error = %v", err)
+	}
+	if got := views["containers"].PinnedLabels; !reflect.DeepEqual(got, []string{"com.example.team", "env"}) {
+		t.Fatalf("containers pinned labels = %v", got)
+	}
+	if got := views["volumes"].PinnedLabels; !reflect.DeepEqual(got, []string{"backup"}) {
+		t.Fatalf("volumes pinned labels = %v", got)
+	}
+	if got, want := views["volumes"].Sort, []SortKey{{Column: "CPU", Desc: true}, {Column: "NAME"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("volumes sort = %v, want %v", got, want)
+	}
+	wantColumns := []ColumnSettings{{Name: "AGE", Hidden: true}, {Name: "NAME", Align: AlignRight}, {Name: "DRIVER"}}
+	if got := views["volumes"].Columns; !reflect.DeepEqual(got, wantColumns) {
+		t.Fatalf("volumes columns = %v, want %v", got, wantColumns)
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/settings"
+)
+
+const (
+	columnsStatusText = "[yellow]Space[white]:show/hide [yellow][ ][white]:move up/down [yellow]-/+[white]:width [yellow]a[white]:align [yellow]r[white]:reset [yellow]Enter[white]:save [yellow]ESC/q[white]:cancel"
+
+	// columnWidthStep is how much - and + change a column's width.
+	columnWidthStep = 5
+)
+
+// columnPicker holds the column layout being edited for a view.
+type columnPicker struct {
+	view    string
+	table   *tview.Table
+	columns []settings.ColumnSettings
+}
+
+// showColumnPicker edits which columns the current view shows, their order,
+// maximum widths and alignment.
+func (u *UI) showColumnPicker() {
+	cp := &columnPicker{
+		view:    u.currentView,
+		table:   tview.NewTable().SetSelectable(true, false).SetFixed(1, 0),
+		columns: u.columnLayout(u.currentView),
+	}
+	cp.table.SetBorder(true).SetTitle(fmt.Sprintf(" Columns: %s ", cp.view))
+	u.setupColumnPickerKeys(cp)
+
+	u.viewMode = "columns"
+	u.updateStatusBarText()
+
+	u.mainView.Clear()
+	u.mainView.AddItem(cp.table, 0, 1, true)
+	u.mainView.AddItem(u.statusBar, 1, 0, false)
+	u.app.SetFocus(cp.table)
+	u.renderColumnPicker(cp, 1)
+}
+
+func (u *UI) setupColumnPickerKeys(cp *columnPicker) {
+	cp.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		row, _ := cp.table.GetSelection()
+		idx := row - 1
+
+		switch event.Key() {
+		case tcell.KeyEscape:
+			u.switchToTableView()
+			return nil
+		case tcell.KeyEnter:
+			u.saveColumnLayout(cp)
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'q':
+			u.switchToTableView()
+			return nil
+		case 'r':
+			cp.columns = viewTables[cp.view].defaults()
+			u.renderColumnPicker(cp, row)
+			return nil
+		}
+
+		if idx < 0 || idx >= len(cp.columns) {
+			return event
+		}
+		c := &cp.columns[idx]
+
+		switch event.Rune() {
+		case ' ':
+			if !c.Hidden && visibleCount(cp.columns) == 1 {
+				u.statusBar.SetText("[yellow]At least one column must stay visible")
+				return nil
+			}
+			c.Hidden = !c.Hidden
+		case '[':
+			if idx == 0 {
+				return nil
+			}
+			cp.columns[idx-1], cp.columns[idx] = cp.columns[idx], cp.columns[idx-1]
+			row--
+		case ']':
+			if idx == len(cp.columns)-1 {
+				return nil
+			}
+			cp.columns[idx+1], cp.columns[idx] = cp.columns[idx], cp.columns[idx+1]
+			row++
+		case '-':
+			c.Width = max(c.Width-columnWidthStep, 0)
+		case '+':
+			c.Width += columnWidthStep
+		case 'a':
+			c.Align = nextAlign(c.Align)
+		default:
+			return event
+		}
+		u.updateStatusBarText()
+		u.renderColumnPicker(cp, row)
+		return nil
+	})
+}
+
+func (u *UI) renderColumnPicker(cp *columnPicker, selectedRow int) {
+	cp.table.Clear()
+
+	headers := []string{"SHOWN", "COLUMN", "WIDTH", "ALIGN"}
+	for col, header := range headers {
+		cp.table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetExpansion(1).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	for i, c := range cp.columns {
+		row := i + 1
+		shown, color := "✓", tcell.ColorWhite
+		if c.Hidden {
+			shown, color = "-", tcell.ColorGray
+		}
+		width := "auto"
+		if c.Width > 0 {
+			width = fmt.Sprintf("%d", c.Width)
+		}
+		align := c.Align
+		if align == "" {
+			align = settings.AlignLeft
+		}
+		cp.table.SetCell(row, 0, tview.NewTableCell(shown).SetTextColor(color).SetExpansion(1))
+		cp.table.SetCell(row, 1, tview.NewTableCell(tview.Escape(c.Name)).SetTextColor(color).SetExpansion(1))
+		cp.table.SetCell(row, 2, tview.NewTableCell(width).SetTextColor(color).SetExpansion(1))
+		cp.table.SetCell(row, 3, tview.NewTableCell(align).SetTextColor(color).SetExpansion(1))
+	}
+
+	cp.table.Select(min(max(selectedRow, 1), len(cp.columns)), 0)
+}
+
+// saveColumnLayout persists the edited layout and returns to the table,
+// reloading it since some columns, such as container sizes, are only
+// fetched while shown.
+func (u *UI) saveColumnLayout(cp *columnPicker) {
+	vs := u.viewSettings[cp.view]
+	vs.Columns = slices.Clone(cp.columns)
+	if err := settings.SaveViewSettings(cp.view, vs); err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Save columns failed: %v", err))
+		return
+	}
+	u.loadViewSettings()
+	u.switchToTableView()
+}
+
+func visibleCount(columns []settings.ColumnSettings) int {
+	n := 0
+	for _, c := range columns {
+		if !c.Hidden {
+			n++
+		}
+	}
+	return n
+}
+
+// nextAlign cycles an alignment from left to center to right.
+func nextAlign(align string) string {
+	switch align {
+	case settings.AlignCenter:
+		return settings.AlignRight
+	case settings.AlignRight:
+		return settings.AlignLeft
+	}
+	return settings.AlignCenter
+}
@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const fieldDiagnoseTarget = "Target container"
+
+// showDiagnoseForm asks for the container the selected one should reach and
+// runs the connectivity diagnostics.
+func (u *UI) showDiagnoseForm(src docker.ContainerInfo) {
+	var targets []docker.ContainerInfo
+	var labels []string
+	for _, ctr := range u.containers {
+		if ctr.ID == src.ID {
+			continue
+		}
+		targets = append(targets, ctr)
+		labels = append(labels, fmt.Sprintf("%s (%s)", ctr.Name, ctr.State))
+	}
+	if len(targets) == 0 {
+		u.statusBar.SetText("[yellow]No other containers to diagnose against")
+		return
+	}
+
+	form := tview.NewForm().
+		AddDropDown(fieldDiagnoseTarget, labels, 0, nil)
+
+	form.AddButton("Diagnose", func() {
+		dd, ok := form.GetFormItemByLabel(fieldDiagnoseTarget).(*tview.DropDown)
+		if !ok {
+			return
+		}
+		idx, _ := dd.GetCurrentOption()
+		if idx < 0 || idx >= len(targets) {
+			return
+		}
+		dst := targets[idx]
+		u.showDetail(fmt.Sprintf(" Connectivity: %s → %s ", src.Name, dst.Name), func() (string, error) {
+			report, err := u.docker.DiagnoseConnectivity(src.ID, dst.ID)
+			if err != nil {
+				return "", err
+			}
+			return formatConnectivityReport(report), nil
+		})
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Diagnose from %s ", src.Name))
+	u.showForm(form)
+}
+
+func formatConnectivityReport(r docker.ConnectivityReport) string {
+	var b strings.Builder
+	if r.Failed() {
+		fmt.Fprintf(&b, "[red]FAIL[white] %s → %s\n\n", tview.Escape(r.Source), tview.Escape(r.Target))
+	} else {
+		fmt.Fprintf(&b, "[green]PASS[white] %s → %s\n\n", tview.Escape(r.Source), tview.Escape(r.Target))
+	}
+
+	b.WriteString("[yellow]Shared networks:[white]\n")
+	if len(r.Shared) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, n := range r.Shared {
+		names := "no DNS (default bridge)"
+		if len(n.Names) > 0 {
+			names = strings.Join(n.Names, ", ")
+		}
+		fmt.Fprintf(&b, "  %-20s %-16s %s\n", tview.Escape(n.Name), dashIfEmpty(n.TargetIP), tview.Escape(names))
+	}
+	fmt.Fprintf(&b, "\n[yellow]Exposed TCP ports:[white] %s\n", joinOrDash(r.Ports))
+
+	if len(r.Problems) > 0 {
+		b.WriteString("\n[yellow]Findings:[white]\n")
+		for _, p := range r.Problems {
+			fmt.Fprintf(&b, "  - %s\n", tview.Escape(p))
+		}
+	}
+
+	if len(r.Probes) > 0 {
+		b.WriteString("\n[yellow]Probes:[white]\n")
+		for _, p := range r.Probes {
+			fmt.Fprintf(&b, "  %s %-4s %-28s %-9s %s\n",
+				probeStatusLabel(p.Status),
+				p.Kind,
+				tview.Escape(p.Target),
+				dashIfEmpty(p.Tool),
+				tview.Escape(p.Reason))
+		}
+	}
+	return b.String()
+}
+
+func probeStatusLabel(status string) string {
+	switch status {
+	case docker.ProbePass:
+		return "[green]PASS[white]"
+	case docker.ProbeFail:
+		return "[red]FAIL[white]"
+	}
+	return "[gray]SKIP[white]"
+}
@@ -0,0 +1,160 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	formStatusText = "[yellow]Tab/Shift+Tab[white]:next/prev field [yellow]Enter[white]:select [yellow]ESC[white]:cancel"
+
+	fieldMemory     = "Memory (e.g. 512m, 1g)"
+	fieldMemorySwap = "Memory+swap (-1 = unlimited)"
+	fieldCPUShares  = "CPU shares"
+	fieldCPUQuota   = "CPU quota (µs)"
+	fieldCPUPeriod  = "CPU period (µs)"
+	fieldCpuset     = "Cpuset CPUs (e.g. 0-3)"
+	fieldPidsLimit  = "PIDs limit"
+	fieldRestart    = "Restart policy"
+	fieldRetries    = "Max retries (on-failure)"
+)
+
+// showResourceEditor loads the container's current limits and opens a form to
+// change them in place.
+func (u *UI) showResourceEditor(ctr docker.ContainerInfo) {
+	u.setStatusMessage(fmt.Sprintf("[yellow]Loading limits for %s...", ctr.Name))
+	go func() {
+		current, err := u.docker.GetContainerResources(ctr.ID)
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]Load limits for %s failed: %v", ctr.Name, err))
+				return
+			}
+			u.showForm(u.buildResourceForm(ctr, current))
+		})
+	}()
+}
+
+func (u *UI) buildResourceForm(ctr docker.ContainerInfo, current docker.ResourceSettings) *tview.Form {
+	input := current.Input()
+
+	policyIndex := 0
+	for i, name := range docker.RestartPolicies {
+		if name == input.RestartPolicy {
+			policyIndex = i
+		}
+	}
+
+	form := tview.NewForm().
+		AddInputField(fieldMemory, input.Memory, 20, nil, nil).
+		AddInputField(fieldMemorySwap, input.MemorySwap, 20, nil, nil).
+		AddInputField(fieldCPUShares, input.CPUShares, 20, nil, nil).
+		AddInputField(fieldCPUQuota, input.CPUQuota, 20, nil, nil).
+		AddInputField(fieldCPUPeriod, input.CPUPeriod, 20, nil, nil).
+		AddInputField(fieldCpuset, input.CpusetCpus, 20, nil, nil).
+		AddInputField(fieldPidsLimit, input.PidsLimit, 20, nil, nil).
+		AddDropDown(fieldRestart, docker.RestartPolicies, policyIndex, nil).
+		AddInputField(fieldRetries, input.MaxRetries, 20, nil, nil)
+
+	form.AddButton("Apply", func() {
+		edited := docker.ResourceInput{
+			Memory:     formText(form, fieldMemory),
+			MemorySwap: formText(form, fieldMemorySwap),
+			CPUShares:  formText(form, fieldCPUShares),
+			CPUQuota:   formText(form, fieldCPUQuota),
+			CPUPeriod:  formText(form, fieldCPUPeriod),
+			CpusetCpus: formText(form, fieldCpuset),
+			PidsLimit:  formText(form, fieldPidsLimit),
+			MaxRetries: formText(form, fieldRetries),
+		}
+		if dd, ok := form.GetFormItemByLabel(fieldRestart).(*tview.DropDown); ok {
+			_, edited.RestartPolicy = dd.GetCurrentOption()
+		}
+		u.applyResourceChanges(ctr, current, edited)
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Edit Limits: %s ", ctr.Name))
+	return form
+}
+
+func (u *UI) applyResourceChanges(ctr docker.ContainerInfo, current docker.ResourceSettings, edited docker.ResourceInput) {
+	updated, err := edited.Parse()
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Invalid limits: %s", strings.ReplaceAll(err.Error(), "\n", "; ")))
+		return
+	}
+
+	changes := current.Changes(updated)
+	if len(changes) == 0 {
+		u.statusBar.SetText("[yellow]No changes to apply")
+		return
+	}
+
+	label := fmt.Sprintf("Update limits for %s", ctr.Name)
+	u.setStatusMessage(fmt.Sprintf("[yellow]%s...", label))
+	go func() {
+		applied, warnings, err := u.docker.UpdateContainerResources(ctr.ID, current, updated)
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]%s failed: %s", label, strings.ReplaceAll(err.Error(), "\n", "; ")))
+				return
+			}
+			changes := current.Changes(applied)
+			u.showDetail(fmt.Sprintf(" Updated Limits: %s ", ctr.Name), func() (string, error) {
+				return formatResourceChanges(changes, warnings), nil
+			})
+		})
+	}()
+}
+
+func formatResourceChanges(changes, warnings []string) string {
+	var b strings.Builder
+	b.WriteString("[green]Applied changes:[white]\n")
+	for _, change := range changes {
+		b.WriteString("  ")
+		b.WriteString(tview.Escape(change))
+		b.WriteByte('\n')
+	}
+	if len(warnings) > 0 {
+		b.WriteString("\n[yellow]Daemon warnings:[white]\n")
+		for _, warning := range warnings {
+			b.WriteString("  ")
+			b.WriteString(tview.Escape(warning))
+			b.WriteByte('\n')
+		}
+	}
+	return b.String()
+}
+
+// showForm swaps the main view for a form, keeping the status bar visible.
+func (u *UI) showForm(form *tview.Form) {
+	form.SetFieldBackgroundColor(tcell.ColorDarkSlateGray).
+		SetButtonBackgroundColor(tcell.ColorDarkSlateGray)
+
+	u.viewMode = "form"
+	u.updateStatusBarText()
+
+	u.mainView.Clear()
+	u.mainView.AddItem(form, 0, 1, true)
+	u.mainView.AddItem(u.statusBar, 1, 0, false)
+
+	u.app.SetFocus(form)
+}
+
+func formText(form *tview.Form, label string) string {
+	if field, ok := form.GetFormItemByLabel(label).(*tview.InputField); ok {
+		return field.GetText()
+	}
+	return ""
+}
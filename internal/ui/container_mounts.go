@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+// showContainerMounts lists the volumes, binds and tmpfs mounts of a
+// container.
+func (u *UI) showContainerMounts(ctr docker.ContainerInfo) {
+	u.showDetail(fmt.Sprintf(" Mounts: %s ", ctr.Name), func() (string, error) {
+		mounts, err := u.docker.ContainerMounts(ctr.ID)
+		if err != nil {
+			return "", err
+		}
+		return formatMounts(mounts), nil
+	})
+}
+
+func formatMounts(mounts []docker.MountInfo) string {
+	if len(mounts) == 0 {
+		return "[gray]No mounts[white]\n"
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "[yellow]%-7s %-40s %-30s %-3s %-12s %s[white]\n", "TYPE", "SOURCE", "DESTINATION", "RW", "PROPAGATION", "MODE")
+	for _, m := range mounts {
+		source := m.Source
+		if m.Name != "" {
+			source = m.Name
+		}
+		rw := "ro"
+		if m.RW {
+			rw = "rw"
+		}
+		fmt.Fprintf(&b, "%-7s %-40s %-30s %-3s %-12s %s\n",
+			tview.Escape(m.Type),
+			tview.Escape(dashIfEmpty(source)),
+			tview.Escape(m.Destination),
+			rw,
+			dashIfEmpty(m.Propagation),
+			tview.Escape(dashIfEmpty(m.Mode)))
+	}
+	return b.String()
+}
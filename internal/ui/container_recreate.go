@@ -0,0 +1,124 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	fieldRecreateImage = "Image"
+	fieldRemoveOld     = "Remove old container"
+	fieldCloneName     = "Name"
+	fieldCloneStart    = "Start clone"
+)
+
+// showRecreateForm asks for an optional new image reference and recreates the
+// container from its current configuration.
+func (u *UI) showRecreateForm(ctr docker.ContainerInfo) {
+	form := tview.NewForm().
+		AddInputField(fieldRecreateImage, ctr.Image, 40, nil, nil).
+		AddCheckbox(fieldRemoveOld, true, nil)
+
+	form.AddButton("Recreate", func() {
+		image := strings.TrimSpace(formText(form, fieldRecreateImage))
+		if image == ctr.Image {
+			image = ""
+		}
+		opts := docker.RecreateOptions{
+			Image:     image,
+			RemoveOld: formChecked(form, fieldRemoveOld),
+		}
+		u.recreateContainer(ctr, opts)
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Recreate: %s ", ctr.Name))
+	u.showForm(form)
+}
+
+func (u *UI) recreateContainer(ctr docker.ContainerInfo, opts docker.RecreateOptions) {
+	label := fmt.Sprintf("Recreate %s", ctr.Name)
+	u.setStatusMessage(fmt.Sprintf("[yellow]%s...", label))
+	go func() {
+		result, err := u.docker.RecreateContainer(ctr.ID, opts)
+		u.app.QueueUpdateDraw(func() {
+			u.showDetail(fmt.Sprintf(" %s ", label), func() (string, error) {
+				return formatRecreateResult(result, err), nil
+			})
+		})
+	}()
+}
+
+func formatRecreateResult(result docker.RecreateResult, err error) string {
+	var b strings.Builder
+	if err != nil {
+		b.WriteString(fmt.Sprintf("[red]Recreate failed:[white] %s\n", tview.Escape(err.Error())))
+		if result.RolledBack {
+			b.WriteString("[yellow]The original container was restored.[white]\n")
+		}
+		return b.String()
+	}
+
+	b.WriteString(fmt.Sprintf("[green]Recreated %s[white]\n", tview.Escape(result.Name)))
+	b.WriteString(fmt.Sprintf("  image:   %s\n", tview.Escape(result.Image)))
+	b.WriteString(fmt.Sprintf("  old ID:  %s\n", shortID(result.OldID)))
+	b.WriteString(fmt.Sprintf("  new ID:  %s\n", shortID(result.NewID)))
+	if result.BackupName != "" {
+		b.WriteString(fmt.Sprintf("  old container kept as %s\n", tview.Escape(result.BackupName)))
+	}
+	return b.String()
+}
+
+// showCloneForm asks for a name and creates a copy of the container.
+func (u *UI) showCloneForm(ctr docker.ContainerInfo) {
+	form := tview.NewForm().
+		AddInputField(fieldCloneName, ctr.Name+"-clone", 40, nil, nil).
+		AddCheckbox(fieldCloneStart, ctr.State == "running", nil)
+
+	form.AddButton("Clone", func() {
+		name := strings.TrimSpace(formText(form, fieldCloneName))
+		if name == "" {
+			u.statusBar.SetText("[red]Clone name is required")
+			return
+		}
+		start := formChecked(form, fieldCloneStart)
+		u.runAsyncAction(fmt.Sprintf("Clone %s as %s", ctr.Name, name), func() error {
+			_, err := u.docker.CloneContainer(ctr.ID, name, start)
+			return err
+		}, func() {
+			u.switchToTableView()
+		})
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Clone: %s ", ctr.Name))
+	u.showForm(form)
+}
+
+func formChecked(form *tview.Form, label string) bool {
+	if box, ok := form.GetFormItemByLabel(label).(*tview.Checkbox); ok {
+		return box.IsChecked()
+	}
+	return false
+}
+
+func shortID(id string) string {
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
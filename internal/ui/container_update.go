@@ -0,0 +1,116 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	updatePullLabel  = "Pull & update"
+	updateLocalLabel = "Update outdated"
+)
+
+// showUpdateDialog offers to update the containers currently shown in the
+// table, either against local images only or after pulling their tags.
+func (u *UI) showUpdateDialog() {
+	targets := u.visibleContainers()
+	if len(targets) == 0 {
+		u.statusBar.SetText("[yellow]No containers to update")
+		return
+	}
+
+	outdated := 0
+	for _, c := range targets {
+		if c.Outdated {
+			outdated++
+		}
+	}
+
+	text := fmt.Sprintf("%d of %d listed containers run an outdated local image.\n\n"+
+		"Pull their tags from the registry first, or only recreate the ones already outdated locally?",
+		outdated, len(targets))
+	modal := tview.NewModal().
+		SetText(text).
+		AddButtons([]string{updatePullLabel, updateLocalLabel, "Cancel"})
+	modal.SetDoneFunc(func(_ int, label string) {
+		u.switchToTableView()
+		switch label {
+		case updatePullLabel:
+			u.updateContainers(targets, true)
+		case updateLocalLabel:
+			u.updateContainers(targets, false)
+		}
+	})
+
+	u.mainView.Clear()
+	u.mainView.AddItem(modal, 0, 1, true)
+	u.mainView.AddItem(u.statusBar, 1, 0, false)
+	u.app.SetFocus(modal)
+}
+
+func (u *UI) updateContainers(targets []docker.ContainerInfo, pull bool) {
+	u.setStatusMessage("[yellow]Updating containers...")
+	go func() {
+		results := u.docker.UpdateContainers(targets, pull, func(done, total int, message string) {
+			u.app.QueueUpdateDraw(func() {
+				u.setStatusMessage(fmt.Sprintf("[yellow]Updating containers (%d/%d): %s", done, total, message))
+			})
+		})
+		u.app.QueueUpdateDraw(func() {
+			u.showDetail(" Image Updates ", func() (string, error) {
+				return formatUpdateResults(results), nil
+			})
+		})
+	}()
+}
+
+func formatUpdateResults(results []docker.UpdateResult) string {
+	counts := make(map[string]int)
+	var b strings.Builder
+	for _, r := range results {
+		counts[r.Status]++
+
+		color := "gray"
+		switch r.Status {
+		case docker.UpdateStatusUpdated:
+			color = "green"
+		case docker.UpdateStatusFailed:
+			color = "red"
+		case docker.UpdateStatusSkipped:
+			color = "yellow"
+		}
+		b.WriteString(fmt.Sprintf("[%s]%-10s[white] %s (%s)", color, r.Status, tview.Escape(r.Container), tview.Escape(r.Image)))
+		if r.Status == docker.UpdateStatusUpdated {
+			b.WriteString(fmt.Sprintf(" %s → %s", shortImage(r.OldImageID), shortImage(r.NewImageID)))
+		}
+		if r.Err != nil {
+			b.WriteString(": " + tview.Escape(r.Err.Error()))
+		}
+		b.WriteByte('\n')
+	}
+
+	summary := fmt.Sprintf("Updated %d, up to date %d, skipped %d, failed %d\n\n",
+		counts[docker.UpdateStatusUpdated], counts[docker.UpdateStatusUpToDate],
+		counts[docker.UpdateStatusSkipped], counts[docker.UpdateStatusFailed])
+	return summary + b.String()
+}
+
+func shortImage(id string) string {
+	return shortID(strings.TrimPrefix(id, "sha256:"))
+}
+
+// visibleContainers returns the containers that pass the active filter, in
+// table order.
+func (u *UI) visibleContainers() []docker.ContainerInfo {
+	visible := make([]docker.ContainerInfo, 0, len(u.containers))
+	for _, c := range u.containers {
+		if u.filter.MatchContainer(c) {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
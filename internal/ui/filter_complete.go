@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/filter"
+)
+
+// maxCompletionsShown caps the completions listed in the status bar.
+const maxCompletionsShown = 12
+
+// completeFilter completes the last term of the filter input: a single
+// option is inserted, several are narrowed to their common prefix and
+// listed in the status bar.
+func (u *UI) completeFilter() {
+	text := u.filterInput.GetText()
+	start, options := filter.Complete(text, filter.Resource(u.currentView), u.completionData())
+
+	switch len(options) {
+	case 0:
+		u.statusBar.SetText("[gray]No completions")
+		return
+	case 1:
+		u.filterInput.SetText(text[:start] + options[0])
+		u.updateStatusBarText()
+		return
+	}
+
+	if prefix := commonPrefix(options); len(prefix) > len(text)-start {
+		u.filterInput.SetText(text[:start] + prefix)
+	}
+	shown := options
+	if len(shown) > maxCompletionsShown {
+		shown = shown[:maxCompletionsShown]
+	}
+	line := tview.Escape(strings.Join(shown, "  "))
+	if len(options) > len(shown) {
+		line += fmt.Sprintf("  (+%d more)", len(options)-len(shown))
+	}
+	u.statusBar.SetText("[yellow]Tab[white]: " + line)
+}
+
+// completionData collects field values and labels from the rows loaded in
+// the current view.
+func (u *UI) completionData() filter.CompletionData {
+	data := filter.CompletionData{
+		Values:      make(map[filter.FilterType][]string),
+		LabelValues: make(map[string][]string),
+	}
+	add := func(t filter.FilterType, value string) {
+		data.Values[t] = append(data.Values[t], value)
+	}
+	addLabels := func(labels map[string]string) {
+		for k, v := range labels {
+			data.LabelValues[k] = append(data.LabelValues[k], v)
+		}
+	}
+
+	switch u.currentView {
+	case "containers":
+		for _, c := range u.containers {
+			add(filter.FilterName, c.Name)
+			add(filter.FilterState, c.State)
+			add(filter.FilterImage, c.Image)
+			for _, n := range c.Networks {
+				add(filter.FilterNetwork, n)
+			}
+			for _, v := range c.Volumes {
+				add(filter.FilterVolume, v)
+			}
+			addLabels(c.Labels)
+		}
+	case "images":
+		for _, img := range u.images {
+			add(filter.FilterTag, img.Tag)
+			add(filter.FilterName, img.Tag)
+			addLabels(img.Labels)
+		}
+	case "networks":
+		for _, net := range u.networks {
+			add(filter.FilterName, net.Name)
+			add(filter.FilterDriver, net.Driver)
+			add(filter.FilterScope, net.Scope)
+			addLabels(net.Labels)
+		}
+	case "volumes":
+		for _, vol := range u.volumes {
+			add(filter.FilterName, vol.Name)
+			add(filter.FilterDriver, vol.Driver)
+			addLabels(vol.Labels)
+		}
+	}
+	return data
+}
+
+func commonPrefix(options []string) string {
+	prefix := options[0]
+	for _, o := range options[1:] {
+		for !strings.HasPrefix(o, prefix) {
+			prefix = prefix[:len(prefix)-1]
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,184 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"dock-it/internal/filter"
+)
+
+const (
+	maxInspectWorkers = 4
+	// inspectRedrawEvery is how many inspections complete between redraws
+	// while a batch loads, so matches appear progressively.
+	inspectRedrawEvery = 25
+)
+
+// inspectKinds maps views to the resource kind docker.InspectJSON takes.
+var inspectKinds = map[string]string{
+	"containers": "container",
+	"images":     "image",
+	"networks":   "network",
+	"volumes":    "volume",
+}
+
+// inspectCache holds the decoded inspect JSON that inspect path criteria
+// are evaluated against. Resources are inspected on demand, only when a
+// filter needs them, and a view's entries are dropped when it reloads.
+// Each clear starts a new generation of a view, so inspections claimed
+// before it cannot store data for the reloaded resources.
+type inspectCache struct {
+	mu          sync.Mutex
+	data        map[string]any
+	pending     map[string]struct{}
+	generations map[filter.Resource]int
+}
+
+func newInspectCache() *inspectCache {
+	return &inspectCache{
+		data:        make(map[string]any),
+		pending:     make(map[string]struct{}),
+		generations: make(map[filter.Resource]int),
+	}
+}
+
+func inspectKey(resource filter.Resource, id string) string {
+	return string(resource) + "/" + id
+}
+
+// Inspected implements filter.InspectSource. A resource that could not be
+// inspected is cached as nil, so it counts as inspected with no data.
+func (c *inspectCache) Inspected(resource filter.Resource, id string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, ok := c.data[inspectKey(resource, id)]
+	return data, ok
+}
+
+// set stores the result of an inspection claimed in generation. It reports
+// false, and stores nothing, when the view has been cleared since.
+func (c *inspectCache) set(resource filter.Resource, generation int, id string, data any) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if generation != c.generations[resource] {
+		return false
+	}
+	key := inspectKey(resource, id)
+	delete(c.pending, key)
+	c.data[key] = data
+	return true
+}
+
+// claim marks the ids that are neither cached nor being inspected as
+// pending and returns them with the view's current generation.
+func (c *inspectCache) claim(resource filter.Resource, ids []string) ([]string, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var claimed []string
+	for _, id := range ids {
+		key := inspectKey(resource, id)
+		if _, ok := c.data[key]; ok {
+			continue
+		}
+		if _, ok := c.pending[key]; ok {
+			continue
+		}
+		c.pending[key] = struct{}{}
+		claimed = append(claimed, id)
+	}
+	return claimed, c.generations[resource]
+}
+
+// clear drops a view's cached inspect data and starts a new generation.
+func (c *inspectCache) clear(resource filter.Resource) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.generations[resource]++
+	prefix := string(resource) + "/"
+	for key := range c.data {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.data, key)
+		}
+	}
+	for key := range c.pending {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.pending, key)
+		}
+	}
+}
+
+// queueInspect inspects the resources of a view that the filter's path
+// criteria still need, in the background, and redraws as results arrive.
+// Progress is shown in the table title.
+func (u *UI) queueInspect(view string, ids []string) {
+	if len(ids) == 0 {
+		return
+	}
+	resource := filter.Resource(view)
+	ids, generation := u.inspect.claim(resource, ids)
+	if len(ids) == 0 {
+		return
+	}
+	u.inspectTotal += len(ids)
+	batch := u.inspectBatch
+
+	kind := inspectKinds[view]
+	go func() {
+		var wg sync.WaitGroup
+		sem := make(chan struct{}, maxInspectWorkers)
+		for _, id := range ids {
+			wg.Add(1)
+			go func(id string) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				// A resource that cannot be inspected, e.g. because it was
+				// removed, is cached as nil rather than retried.
+				data, _ := u.docker.InspectJSON(kind, id)
+				if !u.inspect.set(resource, generation, id, data) {
+					return
+				}
+				u.app.QueueUpdateDraw(func() { u.inspectFinished(batch) })
+			}(id)
+		}
+		wg.Wait()
+	}()
+}
+
+// clearInspect drops a view's inspect data before it reloads and resets the
+// progress count, so inspections still running for the old rows are neither
+// cached nor counted.
+func (u *UI) clearInspect(resource filter.Resource) {
+	u.inspect.clear(resource)
+	u.inspectBatch++
+	u.inspectDone, u.inspectTotal = 0, 0
+}
+
+// inspectFinished counts one completed inspection of batch and redraws the
+// current view every inspectRedrawEvery results and when the last one is
+// in. Inspections from a batch counted before a reload are ignored.
+func (u *UI) inspectFinished(batch int) {
+	if batch != u.inspectBatch {
+		return
+	}
+	u.inspectDone++
+	if u.inspectDone >= u.inspectTotal {
+		u.inspectDone, u.inspectTotal = 0, 0
+		u.redrawCurrentView()
+		return
+	}
+	if u.inspectDone%inspectRedrawEvery == 0 {
+		u.redrawCurrentView()
+	}
+}
+
+// inspectProgress describes a running batch of inspections for the table
+// title, or returns "" when none is running.
+func (u *UI) inspectProgress() string {
+	if u.inspectTotal == 0 {
+		return ""
+	}
+	return fmt.Sprintf("— inspecting %d/%d ", u.inspectDone, u.inspectTotal)
+}
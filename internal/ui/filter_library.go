@@ -0,0 +1,229 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/filter"
+	"dock-it/internal/settings"
+)
+
+const (
+	fieldFilterName   = "Name"
+	fieldFilterQuery  = "Filter"
+	fieldFilterKey    = "Preset key"
+	fieldSavedFilters = "Saved filter"
+
+	noPresetKeyOption = "(none)"
+)
+
+// loadFilterLibrary reads the persisted saved filters and filter history.
+func (u *UI) loadFilterLibrary() {
+	lib, err := settings.LoadFilterLibrary()
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Load saved filters failed: %v", err))
+		return
+	}
+	u.filterLibrary = lib
+}
+
+// showSaveFilterForm saves the active filter under a name for the current
+// view, optionally bound to a preset key.
+func (u *UI) showSaveFilterForm() {
+	view := u.currentView
+	query := u.filter.String()
+
+	name, keyIndex := "", 0
+	for _, sf := range u.filterLibrary.ForView(view) {
+		if sf.Query == query {
+			name = sf.Name
+			keyIndex = presetKeyIndex(sf.Key)
+		}
+	}
+
+	form := tview.NewForm().
+		AddInputField(fieldFilterName, name, 30, nil, nil).
+		AddInputField(fieldFilterQuery, query, 60, nil, nil).
+		AddDropDown(fieldFilterKey, append([]string{noPresetKeyOption}, settings.PresetKeys...), keyIndex, nil)
+
+	form.AddButton("Save", func() {
+		sf := settings.SavedFilter{
+			Name:  formText(form, fieldFilterName),
+			View:  view,
+			Query: formText(form, fieldFilterQuery),
+		}
+		if _, err := filter.ParseFilterFor(sf.Query, filter.Resource(view)); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Filter error: %v", tview.Escape(err.Error())))
+			return
+		}
+		if dd, ok := form.GetFormItemByLabel(fieldFilterKey).(*tview.DropDown); ok {
+			if idx, key := dd.GetCurrentOption(); idx > 0 {
+				sf.Key = key
+			}
+		}
+		if err := settings.SaveFilter(sf); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Save filter failed: %v", err))
+			return
+		}
+		u.loadFilterLibrary()
+		u.switchToTableView()
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Save Filter: %s ", view))
+	u.showForm(form)
+}
+
+// showFilterLibrary lists the filters saved for the current view to apply or
+// delete one.
+func (u *UI) showFilterLibrary() {
+	view := u.currentView
+	saved := u.filterLibrary.ForView(view)
+	if len(saved) == 0 {
+		u.statusBar.SetText(fmt.Sprintf("[yellow]No saved filters for %s; press S to save the active filter", view))
+		return
+	}
+
+	labels := make([]string, len(saved))
+	for i, sf := range saved {
+		label := sf.Name
+		if sf.Key != "" {
+			label += " [" + sf.Key + "]"
+		}
+		labels[i] = tview.Escape(fmt.Sprintf("%s  %s", label, sf.Query))
+	}
+
+	form := tview.NewForm().
+		AddDropDown(fieldSavedFilters, labels, 0, nil)
+
+	selected := func() (settings.SavedFilter, bool) {
+		dd, ok := form.GetFormItemByLabel(fieldSavedFilters).(*tview.DropDown)
+		if !ok {
+			return settings.SavedFilter{}, false
+		}
+		idx, _ := dd.GetCurrentOption()
+		if idx < 0 || idx >= len(saved) {
+			return settings.SavedFilter{}, false
+		}
+		return saved[idx], true
+	}
+
+	form.AddButton("Apply", func() {
+		sf, ok := selected()
+		if !ok {
+			return
+		}
+		if u.setFilterText(sf.Query) {
+			u.switchToTableView()
+		}
+	})
+	form.AddButton("Delete", func() {
+		sf, ok := selected()
+		if !ok {
+			return
+		}
+		if err := settings.DeleteFilter(view, sf.Name); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Delete filter failed: %v", err))
+			return
+		}
+		u.loadFilterLibrary()
+		if len(u.filterLibrary.ForView(view)) == 0 {
+			u.switchToTableView()
+			return
+		}
+		u.showFilterLibrary()
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Saved Filters: %s ", view))
+	u.showForm(form)
+}
+
+// applyPreset applies the filter of the current view bound to key and
+// reports whether one was bound.
+func (u *UI) applyPreset(key tcell.Key) bool {
+	sf, ok := u.filterLibrary.Preset(u.currentView, tcell.KeyNames[key])
+	if !ok {
+		return false
+	}
+	if u.setFilterText(sf.Query) {
+		u.refreshFilteredView()
+	}
+	return true
+}
+
+// setFilterText makes text the active filter and records it in the view's
+// history. An invalid filter is reported and leaves the active one in place.
+func (u *UI) setFilterText(text string) bool {
+	f, err := filter.ParseFilterFor(text, filter.Resource(u.currentView))
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Filter error: %v", tview.Escape(err.Error())))
+		return false
+	}
+	f.Inspect = u.inspect
+	u.filter = f
+	u.filterInput.SetText(text)
+	u.recordFilterHistory(text)
+	u.updateStatusBarText()
+	return true
+}
+
+// recordFilterHistory appends text to the current view's filter history.
+func (u *UI) recordFilterHistory(text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	if err := settings.AddFilterHistory(u.currentView, text); err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Save filter history failed: %v", err))
+		return
+	}
+	u.loadFilterLibrary()
+}
+
+// resetFilterHistory starts history browsing after the newest entry.
+func (u *UI) resetFilterHistory() {
+	u.historyPos = len(u.filterLibrary.History[u.currentView])
+	u.historyDraft = ""
+}
+
+// browseFilterHistory replaces the filter input with an older (step < 0) or
+// newer (step > 0) history entry. Moving past the newest entry restores what
+// was being typed before browsing started.
+func (u *UI) browseFilterHistory(step int) {
+	history := u.filterLibrary.History[u.currentView]
+	pos := u.historyPos + step
+	if pos < 0 || pos > len(history) {
+		return
+	}
+	if u.historyPos == len(history) {
+		u.historyDraft = u.filterInput.GetText()
+	}
+	u.historyPos = pos
+	if pos == len(history) {
+		u.filterInput.SetText(u.historyDraft)
+		return
+	}
+	u.filterInput.SetText(history[pos])
+}
+
+func presetKeyIndex(key string) int {
+	for i, k := range settings.PresetKeys {
+		if k == key {
+			return i + 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,158 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/filter"
+)
+
+const (
+	// filterDebounce is how long typing must pause before the table is
+	// re-filtered.
+	filterDebounce = 150 * time.Millisecond
+	highlightStyle = "[black:yellow]"
+)
+
+// scheduleFilterPreview re-filters the current view from the cached rows
+// once typing pauses. Only the latest keystroke's preview runs.
+func (u *UI) scheduleFilterPreview(text string) {
+	if !u.filterMode {
+		return
+	}
+	u.filterSeq++
+	seq := u.filterSeq
+	time.AfterFunc(filterDebounce, func() {
+		u.app.QueueUpdateDraw(func() {
+			if seq == u.filterSeq && u.filterMode {
+				u.previewFilter(text)
+			}
+		})
+	})
+}
+
+// previewFilter applies text as the filter, without a Docker round trip
+// unless the cached rows were loaded under a filter that may have left out
+// rows it matches. An invalid expression leaves the last valid one in place.
+func (u *UI) previewFilter(text string) {
+	f, err := filter.ParseFilterFor(text, filter.Resource(u.currentView))
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Filter error: %v", tview.Escape(err.Error())))
+		return
+	}
+	f.Inspect = u.inspect
+	u.filter = f
+	u.updateStatusBarText()
+	u.refreshFilteredView()
+}
+
+// cancelFilterInput closes the filter bar and restores the filter that was
+// active when it opened.
+func (u *UI) cancelFilterInput() {
+	u.filterSeq++
+	u.filter = u.filterBefore
+	u.hideFilterInput()
+	u.refreshFilteredView()
+}
+
+// redrawCurrentView re-renders the current view from the cached rows.
+func (u *UI) redrawCurrentView() {
+	selectedRow, _ := u.table.GetSelection()
+	switch u.currentView {
+	case "containers":
+		u.drawContainers(selectedRow)
+	case "images":
+		u.drawImages(selectedRow)
+	case "networks":
+		u.drawNetworks(selectedRow)
+	case "volumes":
+		u.drawVolumes(selectedRow)
+	}
+}
+
+// refreshFilteredView shows the current view under a newly applied filter.
+// Rows cached under a filter the new one does not refine may lack rows it
+// matches, since filters are pushed down to the daemon, so those are
+// reloaded; otherwise the cache is just redrawn.
+func (u *UI) refreshFilteredView() {
+	if loaded := u.loadedFilters[u.currentView]; loaded != nil && !loaded.Covers(u.filter, filter.Resource(u.currentView)) {
+		u.reloadCurrentView()
+		return
+	}
+	u.redrawCurrentView()
+}
+
+// loadServes reports whether rows of view loaded under f can be shown. A
+// load the filter has since moved beyond is dropped; the load started for
+// the newer filter replaces it.
+func (u *UI) loadServes(view string, f *filter.Filter) bool {
+	return u.currentView != view || f.Covers(u.filter, filter.Resource(view))
+}
+
+// countTitle appends the view's active filter and the number of matching
+// rows to a table title while a filter is active.
+func (u *UI) countTitle(title string, shown, total int) string {
+	if u.filter.IsEmpty() {
+		return title
+	}
+	return fmt.Sprintf("%s— %s — %d of %d %s", title, tview.Escape(u.filter.String()), shown, total, u.inspectProgress())
+}
+
+// highlight escapes text for a table cell and marks the parts the active
+// filter matched. searchable and fields are passed to Filter.MatchRanges; a
+// fuzzy filter marks what it matched in the row being drawn instead.
+func (u *UI) highlight(text string, searchable bool, fields ...filter.FilterType) string {
+	ranges := u.filter.MatchRanges(text, searchable, fields...)
+	if u.filter.IsFuzzy() {
+		ranges = u.rowMatch.Ranges(text)
+	}
+	if len(ranges) == 0 {
+		return tview.Escape(text)
+	}
+	var b strings.Builder
+	last := 0
+	for _, r := range ranges {
+		b.WriteString(tview.Escape(text[last:r[0]]))
+		b.WriteString(highlightStyle)
+		b.WriteString(tview.Escape(text[r[0]:r[1]]))
+		b.WriteString("[-:-]")
+		last = r[1]
+	}
+	b.WriteString(tview.Escape(text[last:]))
+	return b.String()
+}
+
+// toggleFuzzyInput switches the filter input between an expression and a
+// fuzzy search by adding or removing the fuzzy prefix.
+func (u *UI) toggleFuzzyInput() {
+	text := u.filterInput.GetText()
+	if rest, ok := strings.CutPrefix(strings.TrimLeft(text, " "), filter.FuzzyPrefix); ok {
+		u.filterInput.SetText(rest)
+		return
+	}
+	u.filterInput.SetText(filter.FuzzyPrefix + text)
+}
+
+// matchFuzzy keeps the rows a fuzzy filter matches and records each match
+// by row id, so ranking and highlighting reuse it instead of matching again.
+func matchFuzzy[T any](u *UI, rows []T, id func(T) string, match func(T) (filter.FuzzyMatch, bool)) []T {
+	u.fuzzyMatches = make(map[string]filter.FuzzyMatch)
+	kept := make([]T, 0, len(rows))
+	for _, row := range rows {
+		if m, ok := match(row); ok {
+			kept = append(kept, row)
+			u.fuzzyMatches[id(row)] = m
+		}
+	}
+	return kept
+}
+
+// rankByScore orders rows by descending fuzzy score, keeping the original
+// order between rows that score the same.
+func rankByScore[T any](rows []T, id func(T) string, matches map[string]filter.FuzzyMatch) {
+	sort.SliceStable(rows, func(a, b int) bool { return matches[id(rows[a])].Score > matches[id(rows[b])].Score })
+}
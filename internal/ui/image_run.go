@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+	"dock-it/internal/settings"
+)
+
+const (
+	fieldRunTemplate     = "Template"
+	fieldRunImage        = "Image"
+	fieldRunName         = "Name"
+	fieldRunCommand      = "Command"
+	fieldRunEnv          = "Env (A=1, B=2)"
+	fieldRunPorts        = "Ports (8080:80, 53:53/udp)"
+	fieldRunMounts       = "Mounts (vol:/data, /host:/path:ro)"
+	fieldRunNetworks     = "Networks"
+	fieldRunLabels       = "Labels (key=value, ...)"
+	fieldRunTemplateName = "Save as template"
+
+	noTemplateOption = "(none)"
+)
+
+// showRunForm opens the run-container wizard for the selected image.
+func (u *UI) showRunForm(img docker.ImageInfo) {
+	templates, err := settings.LoadRunTemplates()
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Load templates failed: %v", err))
+		templates = nil
+	}
+
+	image := img.Tag
+	if image == "<none>" {
+		image = img.ID
+	}
+	initial := docker.RunSpec{Image: image, Resources: docker.ResourceSettings{RestartPolicy: docker.RestartPolicies[0]}}
+	input := initial.Input()
+
+	options := []string{noTemplateOption}
+	for _, t := range templates {
+		options = append(options, t.Name)
+	}
+
+	form := tview.NewForm()
+	form.AddDropDown(fieldRunTemplate, options, 0, func(option string, index int) {
+		if index <= 0 || index > len(templates) {
+			return
+		}
+		spec := templates[index-1].Spec
+		if spec.Image == "" {
+			spec.Image = image
+		}
+		fillRunForm(form, spec.Input())
+		setFormText(form, fieldRunTemplateName, templates[index-1].Name)
+	})
+	addRunFields(form, input)
+	form.AddInputField(fieldRunTemplateName, "", 30, nil, nil)
+
+	form.AddButton("Review", func() {
+		spec, err := readRunForm(form).Parse()
+		if err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Invalid settings: %s", strings.ReplaceAll(err.Error(), "\n", "; ")))
+			return
+		}
+		u.confirmRun(form, spec)
+	})
+	form.AddButton("Save template", func() {
+		name := strings.TrimSpace(formText(form, fieldRunTemplateName))
+		if name == "" {
+			u.statusBar.SetText("[red]Enter a template name first")
+			return
+		}
+		spec, err := readRunForm(form).Parse()
+		if err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Invalid settings: %s", strings.ReplaceAll(err.Error(), "\n", "; ")))
+			return
+		}
+		if err := settings.SaveRunTemplate(settings.RunTemplate{Name: name, Spec: spec}); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Save template failed: %v", err))
+			return
+		}
+		u.statusBar.SetText(fmt.Sprintf("[green]Saved template %s", tview.Escape(name)))
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Run Container: %s ", tview.Escape(image)))
+	u.showForm(form)
+}
+
+// confirmRun shows the equivalent docker run command before creating the
+// container.
+func (u *UI) confirmRun(form *tview.Form, spec docker.RunSpec) {
+	modal := tview.NewModal().
+		SetText("Equivalent command:\n\n" + tview.Escape(spec.DockerRunCommand())).
+		AddButtons([]string{"Create & start", "Back"})
+	modal.SetDoneFunc(func(_ int, label string) {
+		if label != "Create & start" {
+			u.showForm(form)
+			return
+		}
+		u.showForm(form)
+		u.runAsyncAction(fmt.Sprintf("Run %s", tview.Escape(spec.Image)), func() error {
+			_, err := u.docker.RunContainer(spec)
+			return err
+		}, func() {
+			u.currentView = "containers"
+			u.switchToTableView()
+		})
+	})
+
+	u.mainView.Clear()
+	u.mainView.AddItem(modal, 0, 1, true)
+	u.mainView.AddItem(u.statusBar, 1, 0, false)
+	u.app.SetFocus(modal)
+}
+
+// addRunFields adds an input for every RunSpec field, filled from in.
+func addRunFields(form *tview.Form, in docker.RunInput) {
+	policyIndex := 0
+	for i, name := range docker.RestartPolicies {
+		if name == in.Resources.RestartPolicy {
+			policyIndex = i
+		}
+	}
+	form.AddInputField(fieldRunImage, in.Image, 40, nil, nil).
+		AddInputField(fieldRunName, in.Name, 40, nil, nil).
+		AddInputField(fieldRunCommand, in.Command, 40, nil, nil).
+		AddInputField(fieldRunEnv, in.Env, 40, nil, nil).
+		AddInputField(fieldRunPorts, in.Ports, 40, nil, nil).
+		AddInputField(fieldRunMounts, in.Mounts, 40, nil, nil).
+		AddInputField(fieldRunNetworks, in.Networks, 40, nil, nil).
+		AddInputField(fieldRunLabels, in.Labels, 40, nil, nil).
+		AddDropDown(fieldRestart, docker.RestartPolicies, policyIndex, nil).
+		AddInputField(fieldRetries, in.Resources.MaxRetries, 10, nil, nil).
+		AddInputField(fieldMemory, in.Resources.Memory, 10, nil, nil).
+		AddInputField(fieldMemorySwap, in.Resources.MemorySwap, 10, nil, nil).
+		AddInputField(fieldCPUShares, in.Resources.CPUShares, 10, nil, nil).
+		AddInputField(fieldCPUQuota, in.Resources.CPUQuota, 10, nil, nil).
+		AddInputField(fieldCPUPeriod, in.Resources.CPUPeriod, 10, nil, nil).
+		AddInputField(fieldCpuset, in.Resources.CpusetCpus, 10, nil, nil).
+		AddInputField(fieldPidsLimit, in.Resources.PidsLimit, 10, nil, nil)
+}
+
+func readRunForm(form *tview.Form) docker.RunInput {
+	in := docker.RunInput{
+		Image:    formText(form, fieldRunImage),
+		Name:     formText(form, fieldRunName),
+		Command:  formText(form, fieldRunCommand),
+		Env:      formText(form, fieldRunEnv),
+		Ports:    formText(form, fieldRunPorts),
+		Mounts:   formText(form, fieldRunMounts),
+		Networks: formText(form, fieldRunNetworks),
+		Labels:   formText(form, fieldRunLabels),
+		Resources: docker.ResourceInput{
+			Memory:     formText(form, fieldMemory),
+			MemorySwap: formText(form, fieldMemorySwap),
+			CPUShares:  formText(form, fieldCPUShares),
+			CPUQuota:   formText(form, fieldCPUQuota),
+			CPUPeriod:  formText(form, fieldCPUPeriod),
+			CpusetCpus: formText(form, fieldCpuset),
+			PidsLimit:  formText(form, fieldPidsLimit),
+			MaxRetries: formText(form, fieldRetries),
+		},
+	}
+	if dd, ok := form.GetFormItemByLabel(fieldRestart).(*tview.DropDown); ok {
+		_, in.Resources.RestartPolicy = dd.GetCurrentOption()
+	}
+	return in
+}
+
+func fillRunForm(form *tview.Form, in docker.RunInput) {
+	setFormText(form, fieldRunImage, in.Image)
+	setFormText(form, fieldRunName, in.Name)
+	setFormText(form, fieldRunCommand, in.Command)
+	setFormText(form, fieldRunEnv, in.Env)
+	setFormText(form, fieldRunPorts, in.Ports)
+	setFormText(form, fieldRunMounts, in.Mounts)
+	setFormText(form, fieldRunNetworks, in.Networks)
+	setFormText(form, fieldRunLabels, in.Labels)
+	setFormText(form, fieldRetries, in.Resources.MaxRetries)
+	setFormText(form, fieldMemory, in.Resources.Memory)
+	setFormText(form, fieldMemorySwap, in.Resources.MemorySwap)
+	setFormText(form, fieldCPUShares, in.Resources.CPUShares)
+	setFormText(form, fieldCPUQuota, in.Resources.CPUQuota)
+	setFormText(form, fieldCPUPeriod, in.Resources.CPUPeriod)
+	setFormText(form, fieldCpuset, in.Resources.CpusetCpus)
+	setFormText(form, fieldPidsLimit, in.Resources.PidsLimit)
+	if dd, ok := form.GetFormItemByLabel(fieldRestart).(*tview.DropDown); ok {
+		for i, name := range docker.RestartPolicies {
+			if name == in.Resources.RestartPolicy {
+				dd.SetCurrentOption(i)
+			}
+		}
+	}
+}
+
+func setFormText(form *tview.Form, label, text string) {
+	if field, ok := form.GetFormItemByLabel(label).(*tview.InputField); ok {
+		field.SetText(text)
+	}
+}
@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/settings"
+)
+
+const fieldPinnedLabels = "Label keys (comma separated)"
+
+// pinnedLabels returns the label keys pinned as columns in the current view.
+func (u *UI) pinnedLabels() []string {
+	return u.viewSettings[u.currentView].PinnedLabels
+}
+
+// addLabelCells appends the values of the pinned labels to a row.
+func (u *UI) addLabelCells(row, col int, labels map[string]string) {
+	for i, key := range u.pinnedLabels() {
+		value, ok := labels[key]
+		if !ok {
+			value = "-"
+		}
+		u.table.SetCell(row, col+i, tview.NewTableCell(tview.Escape(value)).
+			SetTextColor(tcell.ColorLightCyan).
+			SetExpansion(1))
+	}
+}
+
+// showPinnedLabelsForm edits the label keys pinned as columns in the current
+// view.
+func (u *UI) showPinnedLabelsForm() {
+	view := u.currentView
+	form := tview.NewForm().
+		AddInputField(fieldPinnedLabels, strings.Join(u.pinnedLabels(), ", "), 50, nil, nil)
+
+	form.AddButton("Save", func() {
+		vs := u.viewSettings[view]
+		vs.PinnedLabels = strings.Split(formText(form, fieldPinnedLabels), ",")
+		if err := settings.SaveViewSettings(view, vs); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Save columns failed: %v", err))
+			return
+		}
+		u.loadViewSettings()
+		u.switchToTableView()
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Pinned Labels: %s ", view))
+	u.showForm(form)
+}
+
+// loadViewSettings reads the persisted per-view preferences.
+func (u *UI) loadViewSettings() {
+	views, err := settings.LoadViewSettings()
+	if err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Load view settings failed: %v", err))
+		return
+	}
+	u.viewSettings = views
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	fieldNetworkName       = "Name"
+	fieldNetworkDriver     = "Driver"
+	fieldNetworkSubnet     = "Subnet (CIDR)"
+	fieldNetworkGateway    = "Gateway"
+	fieldNetworkInternal   = "Internal"
+	fieldNetworkAttachable = "Attachable"
+	fieldNetworkLabels     = "Labels (key=value, ...)"
+	fieldNetworkOptions    = "Driver options (key=value, ...)"
+	fieldConnectTarget     = "Target"
+	fieldConnectAliases    = "Aliases (a, b)"
+	fieldConnectIP         = "Static IP"
+	fieldDisconnectForce   = "Force"
+)
+
+var networkDrivers = []string{"bridge", "overlay", "macvlan", "ipvlan"}
+
+// endpointOption is a network or container that can be picked in the
+// connect and disconnect forms.
+type endpointOption struct {
+	ID    string
+	Label string
+}
+
+// showNetworkCreateForm opens a form for creating a network.
+func (u *UI) showNetworkCreateForm() {
+	form := tview.NewForm().
+		AddInputField(fieldNetworkName, "", 30, nil, nil).
+		AddDropDown(fieldNetworkDriver, networkDrivers, 0, nil).
+		AddInputField(fieldNetworkSubnet, "", 20, nil, nil).
+		AddInputField(fieldNetworkGateway, "", 20, nil, nil).
+		AddCheckbox(fieldNetworkInternal, false, nil).
+		AddCheckbox(fieldNetworkAttachable, false, nil).
+		AddInputField(fieldNetworkLabels, "", 40, nil, nil).
+		AddInputField(fieldNetworkOptions, "", 40, nil, nil)
+
+	form.AddButton("Create", func() {
+		in := docker.NetworkInput{
+			Name:       formText(form, fieldNetworkName),
+			Subnet:     formText(form, fieldNetworkSubnet),
+			Gateway:    formText(form, fieldNetworkGateway),
+			Internal:   formChecked(form, fieldNetworkInternal),
+			Attachable: formChecked(form, fieldNetworkAttachable),
+			Labels:     formText(form, fieldNetworkLabels),
+			Options:    formText(form, fieldNetworkOptions),
+		}
+		if dd, ok := form.GetFormItemByLabel(fieldNetworkDriver).(*tview.DropDown); ok {
+			_, in.Driver = dd.GetCurrentOption()
+		}
+		spec, err := in.Parse()
+		if err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Invalid settings: %s", strings.ReplaceAll(err.Error(), "\n", "; ")))
+			return
+		}
+		u.runAsyncAction(fmt.Sprintf("Create network %s", spec.Name), func() error {
+			_, err := u.docker.CreateNetwork(spec)
+			return err
+		}, func() {
+			u.switchToTableView()
+		})
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(" Create Network ")
+	u.showForm(form)
+}
+
+// showConnectContainerForm connects the selected container to a network it
+// is not attached to yet.
+func (u *UI) showConnectContainerForm(ctr docker.ContainerInfo) {
+	u.loadEndpointOptions("networks", func() ([]endpointOption, error) {
+		networks, err := u.docker.ListNetworks()
+		if err != nil {
+			return nil, err
+		}
+		var options []endpointOption
+		for _, n := range networks {
+			if !slices.Contains(ctr.Networks, n.Name) {
+				options = append(options, endpointOption{ID: n.ID, Label: n.Name})
+			}
+		}
+		return options, nil
+	}, func(options []endpointOption) {
+		u.showConnectForm(fmt.Sprintf(" Connect %s to Network ", ctr.Name), options, func(opt endpointOption, endpoint docker.EndpointInput) {
+			u.connectNetwork(opt.ID, opt.Label, ctr.ID, ctr.Name, endpoint)
+		})
+	})
+}
+
+// showConnectNetworkForm connects a container that is not yet a member to
+// the selected network.
+func (u *UI) showConnectNetworkForm(net docker.NetworkInfo) {
+	u.loadEndpointOptions("containers", func() ([]endpointOption, error) {
+		containers, err := u.docker.ListContainers()
+		if err != nil {
+			return nil, err
+		}
+		members := make(map[string]struct{}, len(net.Members))
+		for _, m := range net.Members {
+			members[m.ContainerID] = struct{}{}
+		}
+		var options []endpointOption
+		for _, ctr := range containers {
+			if _, ok := members[ctr.ID]; !ok {
+				options = append(options, endpointOption{ID: ctr.ID, Label: ctr.Name})
+			}
+		}
+		return options, nil
+	}, func(options []endpointOption) {
+		u.showConnectForm(fmt.Sprintf(" Connect Container to %s ", net.Name), options, func(opt endpointOption, endpoint docker.EndpointInput) {
+			u.connectNetwork(net.ID, net.Name, opt.ID, opt.Label, endpoint)
+		})
+	})
+}
+
+// loadEndpointOptions fetches the candidates for a connect form in the
+// background and hands them to show once they are available.
+func (u *UI) loadEndpointOptions(kind string, load func() ([]endpointOption, error), show func([]endpointOption)) {
+	u.setStatusMessage(fmt.Sprintf("[yellow]Loading %s...", kind))
+	go func() {
+		options, err := load()
+		u.app.QueueUpdateDraw(func() {
+			switch {
+			case err != nil:
+				u.statusBar.SetText(fmt.Sprintf("[red]Load %s failed: %v", kind, err))
+			case len(options) == 0:
+				u.statusBar.SetText(fmt.Sprintf("[yellow]No %s available to connect", kind))
+			default:
+				show(options)
+			}
+		})
+	}()
+}
+
+func (u *UI) showConnectForm(title string, options []endpointOption, connect func(endpointOption, docker.EndpointInput)) {
+	labels := make([]string, len(options))
+	for i, opt := range options {
+		labels[i] = opt.Label
+	}
+
+	form := tview.NewForm().
+		AddDropDown(fieldConnectTarget, labels, 0, nil).
+		AddInputField(fieldConnectAliases, "", 30, nil, nil).
+		AddInputField(fieldConnectIP, "", 20, nil, nil)
+
+	form.AddButton("Connect", func() {
+		endpoint := docker.EndpointInput{
+			Aliases: formText(form, fieldConnectAliases),
+			IP:      formText(form, fieldConnectIP),
+		}
+		if _, err := endpoint.Parse(); err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Invalid settings: %v", err))
+			return
+		}
+		dd, ok := form.GetFormItemByLabel(fieldConnectTarget).(*tview.DropDown)
+		if !ok {
+			return
+		}
+		idx, _ := dd.GetCurrentOption()
+		if idx < 0 || idx >= len(options) {
+			return
+		}
+		connect(options[idx], endpoint)
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(title)
+	u.showForm(form)
+}
+
+func (u *UI) connectNetwork(networkID, networkName, containerID, containerName string, endpoint docker.EndpointInput) {
+	u.runAsyncAction(fmt.Sprintf("Connect %s to %s", containerName, networkName), func() error {
+		return u.docker.ConnectNetwork(networkID, containerID, endpoint)
+	}, func() {
+		u.switchToTableView()
+	})
+}
+
+// showDisconnectContainerForm detaches the selected container from one of
+// its networks.
+func (u *UI) showDisconnectContainerForm(ctr docker.ContainerInfo) {
+	if len(ctr.Networks) == 0 {
+		u.statusBar.SetText(fmt.Sprintf("[yellow]%s is not attached to any network", ctr.Name))
+		return
+	}
+	options := make([]endpointOption, len(ctr.Networks))
+	for i, name := range ctr.Networks {
+		options[i] = endpointOption{ID: name, Label: name}
+	}
+	u.showDisconnectForm(fmt.Sprintf(" Disconnect %s from Network ", ctr.Name), options, func(opt endpointOption, force bool) {
+		u.disconnectNetwork(opt.ID, opt.Label, ctr.ID, ctr.Name, force)
+	})
+}
+
+// showDisconnectNetworkForm detaches one of the selected network's members.
+func (u *UI) showDisconnectNetworkForm(net docker.NetworkInfo) {
+	if len(net.Members) == 0 {
+		u.statusBar.SetText(fmt.Sprintf("[yellow]No containers attached to %s", net.Name))
+		return
+	}
+	options := make([]endpointOption, len(net.Members))
+	for i, m := range net.Members {
+		options[i] = endpointOption{ID: m.ContainerID, Label: m.Name}
+	}
+	u.showDisconnectForm(fmt.Sprintf(" Disconnect Container from %s ", net.Name), options, func(opt endpointOption, force bool) {
+		u.disconnectNetwork(net.ID, net.Name, opt.ID, opt.Label, force)
+	})
+}
+
+func (u *UI) showDisconnectForm(title string, options []endpointOption, disconnect func(endpointOption, bool)) {
+	labels := make([]string, len(options))
+	for i, opt := range options {
+		labels[i] = opt.Label
+	}
+
+	form := tview.NewForm().
+		AddDropDown(fieldConnectTarget, labels, 0, nil).
+		AddCheckbox(fieldDisconnectForce, false, nil)
+
+	form.AddButton("Disconnect", func() {
+		dd, ok := form.GetFormItemByLabel(fieldConnectTarget).(*tview.DropDown)
+		if !ok {
+			return
+		}
+		idx, _ := dd.GetCurrentOption()
+		if idx < 0 || idx >= len(options) {
+			return
+		}
+		disconnect(options[idx], formChecked(form, fieldDisconnectForce))
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(title)
+	u.showForm(form)
+}
+
+func (u *UI) disconnectNetwork(networkID, networkName, containerID, containerName string, force bool) {
+	u.runAsyncAction(fmt.Sprintf("Disconnect %s from %s", containerName, networkName), func() error {
+		return u.docker.DisconnectNetwork(networkID, containerID, force)
+	}, func() {
+		u.switchToTableView()
+	})
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+// showNetworkMembers drills into a network, listing its IPAM configuration
+// and every attached container with its addresses and aliases.
+func (u *UI) showNetworkMembers(net docker.NetworkInfo) {
+	title := fmt.Sprintf(" Network: %s ", net.Name)
+	u.showDetail(title, func() (string, error) {
+		members, err := u.docker.InspectNetworkMembers(net)
+		if err != nil {
+			return "", err
+		}
+		net.Members = members
+		return formatNetworkMembers(net), nil
+	})
+}
+
+func formatNetworkMembers(net docker.NetworkInfo) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "[yellow]Driver:[white]   %s (%s)\n", tview.Escape(net.Driver), tview.Escape(net.Scope))
+	fmt.Fprintf(&b, "[yellow]Subnets:[white]  %s\n", tview.Escape(joinOrDash(net.Subnets)))
+	fmt.Fprintf(&b, "[yellow]Gateways:[white] %s\n", tview.Escape(joinOrDash(net.Gateways)))
+	fmt.Fprintf(&b, "[yellow]Flags:[white]    %s\n\n", formatNetworkFlags(net))
+
+	if len(net.Members) == 0 {
+		b.WriteString("[gray]No containers attached[white]\n")
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "[yellow]%-30s %-10s %-20s %-28s %s[white]\n", "CONTAINER", "STATE", "IPV4", "IPV6", "ALIASES")
+	for _, m := range net.Members {
+		fmt.Fprintf(&b, "%-30s %-10s %-20s %-28s %s\n",
+			tview.Escape(m.Name),
+			tview.Escape(m.State),
+			dashIfEmpty(m.IPv4),
+			dashIfEmpty(m.IPv6),
+			tview.Escape(joinOrDash(m.Aliases)))
+	}
+	return b.String()
+}
+
+func formatNetworkFlags(net docker.NetworkInfo) string {
+	var flags []string
+	if net.Internal {
+		flags = append(flags, "internal")
+	}
+	if net.Attachable {
+		flags = append(flags, "attachable")
+	}
+	return joinOrDash(flags)
+}
+
+func joinOrDash(values []string) string {
+	if len(values) == 0 {
+		return "-"
+	}
+	return strings.Join(values, ", ")
+}
+
+func dashIfEmpty(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
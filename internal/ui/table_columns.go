@@ -0,0 +1,502 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+	"dock-it/internal/filter"
+	"dock-it/internal/settings"
+)
+
+// column is one column a view's table can show, named by its header.
+type column[T any] struct {
+	name   string
+	hidden bool   // hidden until shown from the column picker
+	align  string // default alignment; empty for left
+	// cell returns the escaped, possibly highlighted text of the column for
+	// a row and its color.
+	cell    func(u *UI, row T) (string, tcell.Color)
+	compare func(a, b T) int
+}
+
+// tableModel describes a view's table: the columns it can show in default
+// order, the labels behind pinned label columns, and an identity used to
+// break sort ties and to keep the selection on its row.
+type tableModel[T any] struct {
+	columns []column[T]
+	labels  func(T) map[string]string
+	id      func(T) string
+}
+
+// columnSet is the part of a table model that does not depend on its rows.
+type columnSet interface {
+	defaults() []settings.ColumnSettings
+}
+
+func (m tableModel[T]) defaults() []settings.ColumnSettings {
+	out := make([]settings.ColumnSettings, len(m.columns))
+	for i, c := range m.columns {
+		out[i] = settings.ColumnSettings{Name: c.name, Hidden: c.hidden, Align: c.align}
+	}
+	return out
+}
+
+func (m tableModel[T]) column(name string) (column[T], bool) {
+	for _, c := range m.columns {
+		if c.name == name {
+			return c, true
+		}
+	}
+	return column[T]{}, false
+}
+
+var viewTables = map[string]columnSet{
+	"containers": containerTable,
+	"images":     imageTable,
+	"networks":   networkTable,
+	"volumes":    volumeTable,
+}
+
+var containerTable = tableModel[docker.ContainerInfo]{
+	columns: []column[docker.ContainerInfo]{
+		{
+			name:    "STATUS",
+			align:   settings.AlignCenter,
+			cell:    containerStatusCell,
+			compare: byText(func(c docker.ContainerInfo) string { return c.State }),
+		},
+		{
+			name:    "NAME",
+			cell:    containerNameCell,
+			compare: byText(func(c docker.ContainerInfo) string { return c.Name }),
+		},
+		{
+			name:    "AGE",
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return c.Age }),
+			compare: byAge(func(c docker.ContainerInfo) time.Time { return c.Created }),
+		},
+		{
+			name:    "IMAGE",
+			cell:    containerImageCell,
+			compare: byText(formatImage),
+		},
+		{
+			name:    "CPU",
+			cell:    plainCell(tcell.ColorAqua, func(c docker.ContainerInfo) string { return c.CPU }),
+			compare: byUsage(func(u *docker.ResourceUsage) float64 { return u.CPUPercent }),
+		},
+		{
+			name:    "MEMORY",
+			cell:    plainCell(tcell.ColorAqua, func(c docker.ContainerInfo) string { return c.Memory }),
+			compare: byUsage(func(u *docker.ResourceUsage) float64 { return u.MemoryPercent }),
+		},
+		{
+			name:    "NET I/O",
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return c.NetIO }),
+			compare: byUsage(func(u *docker.ResourceUsage) float64 { return float64(u.NetRxBytes + u.NetTxBytes) }),
+		},
+		{
+			name:    "PORTS",
+			cell:    highlightCell(tcell.ColorGray, false, func(c docker.ContainerInfo) string { return c.Ports }),
+			compare: byValue(lowestPort),
+		},
+		{
+			name:    "RESTARTS",
+			cell:    containerRestartsCell,
+			compare: byValue(func(c docker.ContainerInfo) int { return c.RestartCount }),
+		},
+		{
+			name:    "EXIT",
+			cell:    containerExitCell,
+			compare: byValue(exitValue),
+		},
+		{
+			name:    "STARTED",
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return docker.FormatAge(c.StartedAt) }),
+			compare: byAge(func(c docker.ContainerInfo) time.Time { return c.StartedAt }),
+		},
+		{
+			name:    "FINISHED",
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return docker.FormatAge(c.FinishedAt) }),
+			compare: byAge(func(c docker.ContainerInfo) time.Time { return c.FinishedAt }),
+		},
+		{
+			name:    "ID",
+			hidden:  true,
+			cell:    highlightCell(tcell.ColorWhite, true, func(c docker.ContainerInfo) string { return shortID(c.ID) }),
+			compare: byValue(func(c docker.ContainerInfo) string { return c.ID }),
+		},
+		{
+			name:    "COMMAND",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return dashIfEmpty(c.Command) }),
+			compare: byText(func(c docker.ContainerInfo) string { return c.Command }),
+		},
+		{
+			name:    "HEALTH",
+			hidden:  true,
+			cell:    containerHealthCell,
+			compare: byText(func(c docker.ContainerInfo) string { return c.Health }),
+		},
+		{
+			name:    "IPS",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return joinOrDash(c.IPs) }),
+			compare: byAddr(func(c docker.ContainerInfo) []string { return c.IPs }),
+		},
+		{
+			name:    "LABELS",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, func(c docker.ContainerInfo) string { return docker.FormatLabels(c.Labels) }),
+			compare: byText(func(c docker.ContainerInfo) string { return docker.FormatLabels(c.Labels) }),
+		},
+		{
+			name:    "SIZE",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, formatContainerSize),
+			compare: byValue(func(c docker.ContainerInfo) int64 { return c.SizeRw }),
+		},
+	},
+	labels: func(c docker.ContainerInfo) map[string]string { return c.Labels },
+	id:     func(c docker.ContainerInfo) string { return c.ID },
+}
+
+var imageTable = tableModel[docker.ImageInfo]{
+	columns: []column[docker.ImageInfo]{
+		{
+			name:    "ID",
+			cell:    highlightCell(tcell.ColorWhite, true, func(img docker.ImageInfo) string { return img.ID }),
+			compare: byValue(func(img docker.ImageInfo) string { return img.ID }),
+		},
+		{
+			name:    "TAG",
+			cell:    highlightCell(tcell.ColorLightBlue, true, func(img docker.ImageInfo) string { return img.Tag }, filter.FilterTag, filter.FilterName),
+			compare: byText(func(img docker.ImageInfo) string { return img.Tag }),
+		},
+		{
+			name:    "SIZE",
+			cell:    highlightCell(tcell.ColorGray, true, func(img docker.ImageInfo) string { return img.Size }),
+			compare: byValue(func(img docker.ImageInfo) int64 { return img.SizeBytes }),
+		},
+		{
+			name:    "AGE",
+			cell:    plainCell(tcell.ColorGray, func(img docker.ImageInfo) string { return img.Age }),
+			compare: byAge(func(img docker.ImageInfo) time.Time { return img.Created }),
+		},
+		{
+			name:    "LABELS",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, func(img docker.ImageInfo) string { return docker.FormatLabels(img.Labels) }),
+			compare: byText(func(img docker.ImageInfo) string { return docker.FormatLabels(img.Labels) }),
+		},
+	},
+	labels: func(img docker.ImageInfo) map[string]string { return img.Labels },
+	id:     func(img docker.ImageInfo) string { return img.ID },
+}
+
+var networkTable = tableModel[docker.NetworkInfo]{
+	columns: []column[docker.NetworkInfo]{
+		{
+			name:    "ID",
+			cell:    highlightCell(tcell.ColorWhite, true, func(net docker.NetworkInfo) string { return net.ID }),
+			compare: byValue(func(net docker.NetworkInfo) string { return net.ID }),
+		},
+		{
+			name:    "NAME",
+			cell:    highlightCell(tcell.ColorLightBlue, true, func(net docker.NetworkInfo) string { return net.Name }, filter.FilterName),
+			compare: byText(func(net docker.NetworkInfo) string { return net.Name }),
+		},
+		{
+			name:    "AGE",
+			cell:    plainCell(tcell.ColorGray, func(net docker.NetworkInfo) string { return net.Age }),
+			compare: byAge(func(net docker.NetworkInfo) time.Time { return net.Created }),
+		},
+		{
+			name:    "DRIVER",
+			cell:    highlightCell(tcell.ColorGray, true, func(net docker.NetworkInfo) string { return net.Driver }, filter.FilterDriver),
+			compare: byText(func(net docker.NetworkInfo) string { return net.Driver }),
+		},
+		{
+			name:    "SCOPE",
+			cell:    highlightCell(tcell.ColorGray, true, func(net docker.NetworkInfo) string { return net.Scope }, filter.FilterScope),
+			compare: byText(func(net docker.NetworkInfo) string { return net.Scope }),
+		},
+		{
+			name:    "SUBNET",
+			cell:    highlightCell(tcell.ColorGray, false, func(net docker.NetworkInfo) string { return joinOrDash(net.Subnets) }, filter.FilterSubnet),
+			compare: byAddr(func(net docker.NetworkInfo) []string { return net.Subnets }),
+		},
+		{
+			name:    "GATEWAY",
+			cell:    plainCell(tcell.ColorGray, func(net docker.NetworkInfo) string { return joinOrDash(net.Gateways) }),
+			compare: byAddr(func(net docker.NetworkInfo) []string { return net.Gateways }),
+		},
+		{
+			name:    "CONTAINERS",
+			cell:    plainCell(tcell.ColorAqua, func(net docker.NetworkInfo) string { return fmt.Sprintf("%d", len(net.Members)) }),
+			compare: byValue(func(net docker.NetworkInfo) int { return len(net.Members) }),
+		},
+		{
+			name:    "FLAGS",
+			cell:    plainCell(tcell.ColorGray, formatNetworkFlags),
+			compare: byText(formatNetworkFlags),
+		},
+		{
+			name:    "LABELS",
+			hidden:  true,
+			cell:    plainCell(tcell.ColorGray, func(net docker.NetworkInfo) string { return docker.FormatLabels(net.Labels) }),
+			compare: byText(func(net docker.NetworkInfo) string { return docker.FormatLabels(net.Labels) }),
+		},
+	},
+	labels: func(net docker.NetworkInfo) map[string]string { return net.Labels },
+	id:     func(net docker.NetworkInfo) string { return net.ID },
+}
+
+var volumeTable = tableModel[docker.VolumeInfo]{
+	columns: []column[docker.VolumeInfo]{
+		{
+			name:    "NAME",
+			cell:    highlightCell(tcell.ColorWhite, true, func(vol docker.VolumeInfo) string { return vol.Name }, filter.FilterName),
+			compare: byText(func(vol docker.VolumeInfo) string { return vol.Name }),
+		},
+		{
+			name:    "AGE",
+			cell:    plainCell(tcell.ColorGray, func(vol docker.VolumeInfo) string { return vol.Age }),
+			compare: byAge(func(vol docker.VolumeInfo) time.Time { return vol.Created }),
+		},
+		{
+			name:    "DRIVER",
+			cell:    highlightCell(tcell.ColorLightBlue, true, func(vol docker.VolumeInfo) string { return vol.Driver }, filter.FilterDriver),
+			compare: byText(func(vol docker.VolumeInfo) string { return vol.Driver }),
+		},
+		{
+			name:    "USED BY",
+			cell:    volumeUsedByCell,
+			compare: byValue(func(vol docker.VolumeInfo) int { return len(vol.UsedBy) }),
+		},
+		{
+			name:    "LABELS",
+			cell:    plainCell(tcell.ColorGray, func(vol docker.VolumeInfo) string { return docker.FormatLabels(vol.Labels) }),
+			compare: byText(func(vol docker.VolumeInfo) string { return docker.FormatLabels(vol.Labels) }),
+		},
+		{
+			name:    "MOUNTPOINT",
+			cell:    highlightCell(tcell.ColorGray, true, func(vol docker.VolumeInfo) string { return vol.Mountpoint }),
+			compare: byValue(func(vol docker.VolumeInfo) string { return vol.Mountpoint }),
+		},
+	},
+	labels: func(vol docker.VolumeInfo) map[string]string { return vol.Labels },
+	id:     func(vol docker.VolumeInfo) string { return vol.Name },
+}
+
+// plainCell shows a text field of a row in a fixed color.
+func plainCell[T any](color tcell.Color, text func(T) string) func(*UI, T) (string, tcell.Color) {
+	return func(_ *UI, row T) (string, tcell.Color) { return tview.Escape(text(row)), color }
+}
+
+// highlightCell shows a text field of a row in a fixed color with the parts
+// the active filter matched highlighted; searchable and fields are passed to
+// UI.highlight.
+func highlightCell[T any](color tcell.Color, searchable bool, text func(T) string, fields ...filter.FilterType) func(*UI, T) (string, tcell.Color) {
+	return func(u *UI, row T) (string, tcell.Color) { return u.highlight(text(row), searchable, fields...), color }
+}
+
+func containerStatusCell(_ *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	color := tcell.ColorRed
+	if c.State == "running" {
+		color = tcell.ColorGreen
+	} else if c.State == "paused" {
+		color = tcell.ColorYellow
+	}
+	if c.CrashLooping {
+		color = tcell.ColorRed
+	}
+	return "●", color
+}
+
+func containerNameCell(u *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	color := tcell.ColorWhite
+	if c.CrashLooping {
+		color = tcell.ColorRed
+	}
+	return u.highlight(c.Name, true, filter.FilterName), color
+}
+
+func containerImageCell(u *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	color := tcell.ColorLightBlue
+	if c.Outdated {
+		color = tcell.ColorYellow
+	}
+	text := u.highlight(c.Image, true)
+	if c.Outdated {
+		text += " (outdated)"
+	}
+	return text, color
+}
+
+func containerRestartsCell(_ *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	color := tcell.ColorGray
+	if c.CrashLooping {
+		color = tcell.ColorRed
+	}
+	return formatRestarts(c), color
+}
+
+func containerExitCell(_ *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	color := tcell.ColorGray
+	if c.OOMKilled || (c.State != "running" && c.ExitCode != 0) {
+		color = tcell.ColorRed
+	}
+	return formatExit(c), color
+}
+
+func containerHealthCell(_ *UI, c docker.ContainerInfo) (string, tcell.Color) {
+	switch c.Health {
+	case "healthy":
+		return c.Health, tcell.ColorGreen
+	case "unhealthy":
+		return c.Health, tcell.ColorRed
+	case "starting":
+		return c.Health, tcell.ColorYellow
+	}
+	return tview.Escape(dashIfEmpty(c.Health)), tcell.ColorGray
+}
+
+func volumeUsedByCell(_ *UI, vol docker.VolumeInfo) (string, tcell.Color) {
+	color := tcell.ColorWhite
+	if len(vol.UsedBy) == 0 {
+		color = tcell.ColorGray
+	}
+	return tview.Escape(docker.FormatVolumeUsers(vol.UsedBy)), color
+}
+
+// formatContainerSize shows a container's writable layer size and its total
+// size including the image, or "-" when sizes were not fetched.
+func formatContainerSize(c docker.ContainerInfo) string {
+	if c.SizeRootFs == 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%s (virtual %s)", units.HumanSize(float64(c.SizeRw)), units.HumanSize(float64(c.SizeRootFs)))
+}
+
+// resolveColumns applies a view's saved column layout to its default
+// columns. Saved columns come first in their saved order, then the columns
+// the layout does not list, in default order. Saved columns the view does
+// not have are dropped.
+func resolveColumns(defaults, saved []settings.ColumnSettings) []settings.ColumnSettings {
+	byName := make(map[string]settings.ColumnSettings, len(defaults))
+	for _, d := range defaults {
+		byName[d.Name] = d
+	}
+	out := make([]settings.ColumnSettings, 0, len(defaults))
+	placed := make(map[string]bool, len(defaults))
+	for _, s := range saved {
+		d, ok := byName[s.Name]
+		if !ok || placed[s.Name] {
+			continue
+		}
+		if s.Align == "" {
+			s.Align = d.Align
+		}
+		out = append(out, s)
+		placed[s.Name] = true
+	}
+	for _, d := range defaults {
+		if !placed[d.Name] {
+			out = append(out, d)
+		}
+	}
+	return out
+}
+
+// columnLayout returns every column of view, shown or hidden, in display
+// order.
+func (u *UI) columnLayout(view string) []settings.ColumnSettings {
+	return resolveColumns(viewTables[view].defaults(), u.viewSettings[view].Columns)
+}
+
+// visibleColumns returns the columns view shows, in display order.
+func (u *UI) visibleColumns(view string) []settings.ColumnSettings {
+	var out []settings.ColumnSettings
+	for _, c := range u.columnLayout(view) {
+		if !c.Hidden {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// columnVisible reports whether view shows the named column.
+func (u *UI) columnVisible(view, name string) bool {
+	for _, c := range u.visibleColumns(view) {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// drawTable fills the table with a header and rows for the current view's
+// visible columns, followed by its pinned label columns.
+func drawTable[T any](u *UI, m tableModel[T], rows []T) {
+	layout := u.visibleColumns(u.currentView)
+	columns := make([]column[T], len(layout))
+	names := make([]string, len(layout))
+	for i, cs := range layout {
+		columns[i], _ = m.column(cs.Name)
+		names[i] = cs.Name
+	}
+	u.addHeaders(names)
+
+	for i, row := range rows {
+		u.rowMatch = u.fuzzyMatches[m.id(row)]
+		for col, c := range columns {
+			text, color := c.cell(u, row)
+			u.table.SetCell(i+1, col, tview.NewTableCell(text).
+				SetTextColor(color).
+				SetAlign(cellAlign(layout[col].Align)).
+				SetMaxWidth(layout[col].Width).
+				SetExpansion(1))
+		}
+		u.addLabelCells(i+1, len(columns), m.labels(row))
+	}
+}
+
+// addHeaders sets the header row: headers followed by the pinned label
+// columns, each marked with its place in the sort order. The column under
+// the sort cursor is underlined.
+func (u *UI) addHeaders(headers []string) {
+	cursor := u.sortCursor()
+	for col, column := range u.columnsOf(headers) {
+		text := column
+		if col >= len(headers) {
+			text = strings.ToUpper(strings.TrimPrefix(column, labelColumnPrefix))
+		}
+		attrs := tcell.AttrBold
+		if col == cursor {
+			attrs |= tcell.AttrUnderline
+		}
+		u.table.SetCell(0, col, tview.NewTableCell(tview.Escape(text+u.sortIndicator(column))).
+			SetTextColor(tcell.ColorYellow).
+			SetAlign(tview.AlignCenter).
+			SetSelectable(false).
+			SetExpansion(1).
+			SetAttributes(attrs))
+	}
+}
+
+func cellAlign(align string) int {
+	switch align {
+	case settings.AlignCenter:
+		return tview.AlignCenter
+	case settings.AlignRight:
+		return tview.AlignRight
+	}
+	return tview.AlignLeft
+}
@@ -0,0 +1,232 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+	"time"
+
+	"dock-it/internal/docker"
+	"dock-it/internal/settings"
+)
+
+// labelColumnPrefix marks the sort column of a pinned label.
+const labelColumnPrefix = "label."
+
+// sort orders rows by keys. Rows equal on every key are ordered by id so the
+// order does not change between refreshes. Without keys rows keep the order
+// the Docker API returned.
+func (m tableModel[T]) sort(rows []T, keys []settings.SortKey) {
+	var compares []func(a, b T) int
+	for _, key := range keys {
+		c, _ := m.column(key.Column)
+		compare := c.compare
+		if label, ok := strings.CutPrefix(key.Column, labelColumnPrefix); ok {
+			compare = func(a, b T) int { return compareText(m.labels(a)[label], m.labels(b)[label]) }
+		}
+		if compare == nil {
+			continue
+		}
+		if key.Desc {
+			asc := compare
+			compare = func(a, b T) int { return asc(b, a) }
+		}
+		compares = append(compares, compare)
+	}
+	if len(compares) == 0 {
+		return
+	}
+	compares = append(compares, func(a, b T) int { return strings.Compare(m.id(a), m.id(b)) })
+
+	slices.SortStableFunc(rows, func(a, b T) int {
+		for _, compare := range compares {
+			if c := compare(a, b); c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+}
+
+// byValue compares rows by an ordered value of a column.
+func byValue[T any, V cmp.Ordered](value func(T) V) func(a, b T) int {
+	return func(a, b T) int { return cmp.Compare(value(a), value(b)) }
+}
+
+// byText compares rows by the text of a column, ignoring case.
+func byText[T any](text func(T) string) func(a, b T) int {
+	return func(a, b T) int { return compareText(text(a), text(b)) }
+}
+
+// byAge compares rows by the age a time column displays: the most recent
+// first. A zero time counts as the oldest.
+func byAge[T any](at func(T) time.Time) func(a, b T) int {
+	return func(a, b T) int { return at(b).Compare(at(a)) }
+}
+
+// byAddr compares rows by the first address or CIDR prefix of a column.
+func byAddr[T any](values func(T) []string) func(a, b T) int {
+	return func(a, b T) int { return firstAddr(values(a)).Compare(firstAddr(values(b))) }
+}
+
+// byUsage compares containers by a stats figure. Containers without stats
+// sort before those with any.
+func byUsage(value func(*docker.ResourceUsage) float64) func(a, b docker.ContainerInfo) int {
+	return byValue(func(c docker.ContainerInfo) float64 {
+		if c.Usage == nil {
+			return -1
+		}
+		return value(c.Usage)
+	})
+}
+
+func compareText(a, b string) int {
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// lowestPort returns the lowest published port of a container, or its
+// lowest exposed port when none is published, or -1 without ports.
+func lowestPort(c docker.ContainerInfo) int {
+	published, exposed := -1, -1
+	for _, p := range c.PortMappings {
+		if p.PublicPort != 0 && (published < 0 || int(p.PublicPort) < published) {
+			published = int(p.PublicPort)
+		}
+		if exposed < 0 || int(p.PrivatePort) < exposed {
+			exposed = int(p.PrivatePort)
+		}
+	}
+	if published >= 0 {
+		return published
+	}
+	return exposed
+}
+
+// exitValue is the exit code shown for a container, or -1 where none is.
+func exitValue(c docker.ContainerInfo) int {
+	if c.State == "running" || c.FinishedAt.IsZero() {
+		return -1
+	}
+	return c.ExitCode
+}
+
+// firstAddr parses the first of a list of addresses or CIDR prefixes. The
+// zero Addr it returns for an empty or invalid list sorts first.
+func firstAddr(values []string) netip.Addr {
+	if len(values) == 0 {
+		return netip.Addr{}
+	}
+	if prefix, err := netip.ParsePrefix(values[0]); err == nil {
+		return prefix.Addr()
+	}
+	addr, _ := netip.ParseAddr(values[0])
+	return addr
+}
+
+// followSelection returns the row now showing the item that prev showed at
+// selectedRow, so the selection stays on it when rows move. selectedRow is
+// returned when the item is no longer shown.
+func followSelection[T any](prev, rows []T, selectedRow int, id func(T) string) int {
+	if selectedRow < 1 || selectedRow > len(prev) {
+		return selectedRow
+	}
+	want := id(prev[selectedRow-1])
+	for i, row := range rows {
+		if id(row) == want {
+			return i + 1
+		}
+	}
+	return selectedRow
+}
+
+// sortKeys returns the current view's sort order.
+func (u *UI) sortKeys() []settings.SortKey {
+	return u.viewSettings[u.currentView].Sort
+}
+
+// tableColumns returns the sort columns of the current view.
+func (u *UI) tableColumns() []string {
+	var headers []string
+	for _, c := range u.visibleColumns(u.currentView) {
+		headers = append(headers, c.Name)
+	}
+	return u.columnsOf(headers)
+}
+
+// columnsOf returns headers followed by the current view's pinned label
+// columns.
+func (u *UI) columnsOf(headers []string) []string {
+	columns := slices.Clone(headers)
+	for _, key := range u.pinnedLabels() {
+		columns = append(columns, labelColumnPrefix+key)
+	}
+	return columns
+}
+
+// sortCursor returns the column of the current view that o and O act on.
+func (u *UI) sortCursor() int {
+	return min(u.sortCursors[u.currentView], len(u.tableColumns())-1)
+}
+
+// moveSortCursor moves the sort column cursor step columns to the right.
+func (u *UI) moveSortCursor(step int) {
+	cursor := u.sortCursor() + step
+	if cursor < 0 || cursor >= len(u.tableColumns()) {
+		return
+	}
+	u.sortCursors[u.currentView] = cursor
+	u.redrawCurrentView()
+}
+
+// sortByCursor sorts the current view by the column under the sort cursor.
+// As the primary key (secondary false) the column replaces the sort order;
+// as a secondary key it is appended to it. Sorting again by a column that is
+// already the key cycles it from ascending to descending to removed.
+func (u *UI) sortByCursor(secondary bool) {
+	column := u.tableColumns()[u.sortCursor()]
+	keys := slices.Clone(u.sortKeys())
+	i := slices.IndexFunc(keys, func(k settings.SortKey) bool { return k.Column == column })
+
+	switch {
+	case i < 0 && secondary:
+		keys = append(keys, settings.SortKey{Column: column})
+	case i < 0 || (i > 0 && !secondary):
+		keys = []settings.SortKey{{Column: column}}
+	case !keys[i].Desc:
+		keys[i].Desc = true
+	default:
+		keys = slices.Delete(keys, i, i+1)
+	}
+
+	view := u.currentView
+	vs := u.viewSettings[view]
+	vs.Sort = keys
+	if err := settings.SaveViewSettings(view, vs); err != nil {
+		u.statusBar.SetText(fmt.Sprintf("[red]Save sort order failed: %v", err))
+		return
+	}
+	u.loadViewSettings()
+	u.redrawCurrentView()
+}
+
+// sortIndicator marks a column header with its sort direction, and with its
+// place in the sort order when there are several keys.
+func (u *UI) sortIndicator(column string) string {
+	keys := u.sortKeys()
+	for i, key := range keys {
+		if key.Column != column {
+			continue
+		}
+		arrow := " ▲"
+		if key.Desc {
+			arrow = " ▼"
+		}
+		if len(keys) > 1 {
+			arrow += fmt.Sprint(i + 1)
+		}
+		return arrow
+	}
+	return ""
+}
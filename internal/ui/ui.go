@@ -14,6 +14,7 @@ import (
 	"dock-it/internal/docker"
 	"dock-it/internal/filter"
 	"dock-it/internal/logs"
+	"dock-it/internal/settings"
 )
 
 // UI manages the terminal interface and orchestrates Docker operations.
@@ -31,14 +32,60 @@ type UI struct {
 	volumes     []docker.VolumeInfo
 	viewMode    string
 	currentView string
-	filter      *filter.Filter
+	filter      *filter.Filter // filter of the current view
 	filterMode  bool
+
+	// Filter, selection and scroll offset of the views not shown.
+	viewStates map[string]viewState
+
+	// Column each view's sort keys act on.
+	sortCursors map[string]int
+
+	// Rows currently shown in the table, after filtering; row i+1 of the
+	// table is element i.
+	shownContainers []docker.ContainerInfo
+	shownImages     []docker.ImageInfo
+	shownNetworks   []docker.NetworkInfo
+	shownVolumes    []docker.VolumeInfo
+
+	filterBefore *filter.Filter // filter to restore when the filter bar is cancelled
+	filterSeq    int            // invalidates pending debounced previews
+
+	// How each shown row matched a fuzzy filter, by row id, and the match
+	// of the row drawTable is drawing.
+	fuzzyMatches map[string]filter.FuzzyMatch
+	rowMatch     filter.FuzzyMatch
+
+	// Filter each view's cached rows were loaded with; rows it excluded
+	// were never fetched. totals counts every row of a view, including
+	// those.
+	loadedFilters map[string]*filter.Filter
+	totals        map[string]int
+
+	// Inspect data for inspect path criteria, and progress of the running
+	// batch of inspections.
+	inspect      *inspectCache
+	inspectBatch int
+	inspectDone  int
+	inspectTotal int
+
+	viewSettings map[string]settings.ViewSettings
+
+	// Saved filters and filter history, and the history entry shown in the
+	// filter input while browsing it with the arrow keys.
+	filterLibrary settings.FilterLibrary
+	historyPos    int
+	historyDraft  string
+
+	// volumeBrowser is the open volume file browser, whose helper container
+	// is removed on exit if it is still open.
+	volumeBrowser *volumeBrowser
 }
 
 const (
-	tableStatusText  = "[yellow]1[white]:containers [yellow]2[white]:images [yellow]3[white]:networks [yellow]4[white]:volumes | [yellow]/[white]:search [yellow]c[white]:clear [yellow]s[white]:start [yellow]x[white]:stop [yellow]d[white]:delete [yellow]i[white]:describe [yellow]q[white]:quit"
+	tableStatusText  = "[yellow]1[white]:containers [yellow]2[white]:images [yellow]3[white]:networks [yellow]4[white]:volumes | [yellow]/[white]:search [yellow]c[white]:clear [yellow]S[white]:save filter [yellow]F[white]:saved filters [yellow]F1-F12[white]:presets [yellow]L[white]:label columns [yellow]V[white]:columns [yellow]</>[white]:sort column [yellow]o[white]:sort [yellow]O[white]:then sort [yellow]s[white]:start [yellow]x[white]:stop [yellow]d[white]:delete [yellow]i[white]:describe [yellow]u[white]:limits [yellow]U[white]:recreate [yellow]C[white]:clone [yellow]P[white]:update images [yellow]a[white]:connect [yellow]D[white]:disconnect [yellow]n[white]:new network/volume [yellow]T[white]:diagnose [yellow]M[white]:mounts [yellow]b[white]:backup [yellow]r[white]:restore [yellow]f[white]:files [yellow]q[white]:quit"
 	detailStatusText = "[yellow]ESC/q[white]:back [yellow]↑↓[white]:scroll"
-	filterStatusText = "[yellow]Enter[white]:search [yellow]ESC[white]:cancel [yellow]Ctrl+U[white]:clear | Search across name, image, status, etc. or use advanced: [gray]age>1h, status=running[white]"
+	filterStatusText = "[yellow]Enter[white]:search [yellow]Tab[white]:complete [yellow]Ctrl+F[white]:fuzzy [yellow]↑↓[white]:history [yellow]ESC[white]:cancel [yellow]Ctrl+U[white]:clear | Search across name, image, status, etc., combine criteria: [gray]age>1h, (state=running or state=restarting) and not name~test[white], or fuzzy rank with [gray]?dkreg[white]"
 	containersTitle  = " Docker Containers (dock-it) "
 	imagesTitle      = " Docker Images "
 	networksTitle    = " Docker Networks "
@@ -54,6 +101,12 @@ func New(dockerClient *docker.Client) *UI {
 		currentView: "containers",
 		filter:      filter.New(),
 		filterMode:  false,
+
+		viewStates:    make(map[string]viewState),
+		sortCursors:   make(map[string]int),
+		loadedFilters: make(map[string]*filter.Filter),
+		totals:        make(map[string]int),
+		inspect:       newInspectCache(),
 	}
 }
 
@@ -86,33 +139,39 @@ func (u *UI) Initialize() {
 		SetFieldBackgroundColor(tcell.ColorBlack).
 		SetPlaceholder("Type to search across all fields (or use advanced filters like age>1h)")
 	u.filterInput.SetBorder(true).SetTitle(" Search/Filter ")
+	u.filterInput.SetChangedFunc(u.scheduleFilterPreview)
 
 	u.statusBar = tview.NewTextView().
 		SetDynamicColors(true)
 	u.updateStatusBarText()
 
 	u.setupKeyBindings()
+	u.loadViewSettings()
+	u.loadFilterLibrary()
 	u.loadContainers()
 }
 
 func (u *UI) setupKeyBindings() {
 	u.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() >= tcell.KeyF1 && event.Key() <= tcell.KeyF12 {
+			if u.applyPreset(event.Key()) {
+				return nil
+			}
+			return event
+		}
+
 		switch event.Rune() {
 		case '1':
-			u.currentView = "containers"
-			u.loadContainers()
+			u.switchView("containers")
 			return nil
 		case '2':
-			u.currentView = "images"
-			u.loadImages()
+			u.switchView("images")
 			return nil
 		case '3':
-			u.currentView = "networks"
-			u.loadNetworks()
+			u.switchView("networks")
 			return nil
 		case '4':
-			u.currentView = "volumes"
-			u.loadVolumes()
+			u.switchView("volumes")
 			return nil
 		case '/':
 			u.showFilterInput()
@@ -122,9 +181,33 @@ func (u *UI) setupKeyBindings() {
 				u.clearFilter()
 			}
 			return nil
+		case 'S':
+			u.showSaveFilterForm()
+			return nil
+		case 'F':
+			u.showFilterLibrary()
+			return nil
 		case 'R':
 			u.reloadCurrentView()
 			return nil
+		case 'L':
+			u.showPinnedLabelsForm()
+			return nil
+		case 'V':
+			u.showColumnPicker()
+			return nil
+		case '<':
+			u.moveSortCursor(-1)
+			return nil
+		case '>':
+			u.moveSortCursor(1)
+			return nil
+		case 'o':
+			u.sortByCursor(false)
+			return nil
+		case 'O':
+			u.sortByCursor(true)
+			return nil
 		case 'q':
 			u.app.Stop()
 			return nil
@@ -134,11 +217,11 @@ func (u *UI) setupKeyBindings() {
 		case "containers":
 			row, _ := u.table.GetSelection()
 			idx := row - 1
-			if idx < 0 || idx >= len(u.containers) {
+			if idx < 0 || idx >= len(u.shownContainers) {
 				return event
 			}
 
-			selectedContainer := u.containers[idx]
+			selectedContainer := u.shownContainers[idx]
 
 			switch event.Rune() {
 			case 's':
@@ -186,15 +269,39 @@ func (u *UI) setupKeyBindings() {
 					u.execContainer(selectedContainer)
 				}
 				return nil
+			case 'u':
+				u.showResourceEditor(selectedContainer)
+				return nil
+			case 'U':
+				u.showRecreateForm(selectedContainer)
+				return nil
+			case 'C':
+				u.showCloneForm(selectedContainer)
+				return nil
+			case 'P':
+				u.showUpdateDialog()
+				return nil
+			case 'a':
+				u.showConnectContainerForm(selectedContainer)
+				return nil
+			case 'D':
+				u.showDisconnectContainerForm(selectedContainer)
+				return nil
+			case 'T':
+				u.showDiagnoseForm(selectedContainer)
+				return nil
+			case 'M':
+				u.showContainerMounts(selectedContainer)
+				return nil
 			}
 		case "images":
 			row, _ := u.table.GetSelection()
 			idx := row - 1
-			if idx < 0 || idx >= len(u.images) {
+			if idx < 0 || idx >= len(u.shownImages) {
 				return event
 			}
 
-			selectedImage := u.images[idx]
+			selectedImage := u.shownImages[idx]
 
 			switch event.Rune() {
 			case 'd':
@@ -207,15 +314,28 @@ func (u *UI) setupKeyBindings() {
 			case 'i':
 				u.describeImage(selectedImage)
 				return nil
+			case 'r':
+				u.showRunForm(selectedImage)
+				return nil
 			}
 		case "networks":
+			if event.Rune() == 'n' {
+				u.showNetworkCreateForm()
+				return nil
+			}
+
 			row, _ := u.table.GetSelection()
 			idx := row - 1
-			if idx < 0 || idx >= len(u.networks) {
+			if idx < 0 || idx >= len(u.shownNetworks) {
 				return event
 			}
 
-			selectedNetwork := u.networks[idx]
+			selectedNetwork := u.shownNetworks[idx]
+
+			if event.Key() == tcell.KeyEnter {
+				u.showNetworkMembers(selectedNetwork)
+				return nil
+			}
 
 			switch event.Rune() {
 			case 'd':
@@ -228,15 +348,26 @@ func (u *UI) setupKeyBindings() {
 			case 'i':
 				u.describeNetwork(selectedNetwork)
 				return nil
+			case 'a':
+				u.showConnectNetworkForm(selectedNetwork)
+				return nil
+			case 'D':
+				u.showDisconnectNetworkForm(selectedNetwork)
+				return nil
 			}
 		case "volumes":
+			if event.Rune() == 'n' {
+				u.showVolumeCreateForm()
+				return nil
+			}
+
 			row, _ := u.table.GetSelection()
 			idx := row - 1
-			if idx < 0 || idx >= len(u.volumes) {
+			if idx < 0 || idx >= len(u.shownVolumes) {
 				return event
 			}
 
-			selectedVolume := u.volumes[idx]
+			selectedVolume := u.shownVolumes[idx]
 
 			switch event.Rune() {
 			case 'd':
@@ -249,6 +380,15 @@ func (u *UI) setupKeyBindings() {
 			case 'i':
 				u.describeVolume(selectedVolume)
 				return nil
+			case 'b':
+				u.showVolumeTransferForm(selectedVolume, false)
+				return nil
+			case 'r':
+				u.showVolumeTransferForm(selectedVolume, true)
+				return nil
+			case 'f':
+				u.showVolumeBrowserForm(selectedVolume)
+				return nil
 			}
 		}
 
@@ -273,11 +413,23 @@ func (u *UI) setupKeyBindings() {
 			u.applyFilter()
 			return nil
 		case tcell.KeyEscape:
-			u.hideFilterInput()
+			u.cancelFilterInput()
 			return nil
 		case tcell.KeyCtrlU:
 			u.filterInput.SetText("")
 			return nil
+		case tcell.KeyTab:
+			u.completeFilter()
+			return nil
+		case tcell.KeyCtrlF:
+			u.toggleFuzzyInput()
+			return nil
+		case tcell.KeyUp:
+			u.browseFilterHistory(-1)
+			return nil
+		case tcell.KeyDown:
+			u.browseFilterHistory(1)
+			return nil
 		}
 		return event
 	})
@@ -292,6 +444,18 @@ func (u *UI) updateStatusBarText() {
 		u.statusBar.SetText(detailStatusText)
 		return
 	}
+	if u.viewMode == "form" {
+		u.statusBar.SetText(formStatusText)
+		return
+	}
+	if u.viewMode == "browser" {
+		u.statusBar.SetText(browserStatusText)
+		return
+	}
+	if u.viewMode == "columns" {
+		u.statusBar.SetText(columnsStatusText)
+		return
+	}
 	if u.filterMode {
 		u.statusBar.SetText(filterStatusText)
 		return
@@ -330,7 +494,9 @@ func (u *UI) showLoading(title string) {
 }
 
 func (u *UI) showFilterInput() {
+	u.filterBefore = u.filter
 	u.filterMode = true
+	u.resetFilterHistory()
 	u.updateStatusBarText()
 
 	// Set initial text if filter exists
@@ -360,22 +526,25 @@ func (u *UI) hideFilterInput() {
 func (u *UI) applyFilter() {
 	filterText := u.filterInput.GetText()
 
-	newFilter, err := filter.ParseFilter(filterText)
+	newFilter, err := filter.ParseFilterFor(filterText, filter.Resource(u.currentView))
 	if err != nil {
-		u.statusBar.SetText(fmt.Sprintf("[red]Filter error: %v", err))
+		u.statusBar.SetText(fmt.Sprintf("[red]Filter error: %v", tview.Escape(err.Error())))
 		return
 	}
 
+	u.filterSeq++
+	newFilter.Inspect = u.inspect
 	u.filter = newFilter
+	u.recordFilterHistory(filterText)
 	u.hideFilterInput()
-	u.reloadCurrentView()
+	u.refreshFilteredView()
 }
 
 func (u *UI) clearFilter() {
 	u.filter = filter.New()
 	u.filterInput.SetText("")
 	u.updateStatusBarText()
-	u.reloadCurrentView()
+	u.refreshFilteredView()
 }
 
 func (u *UI) reloadCurrentView() {
@@ -546,162 +715,227 @@ func preferredShells() []string {
 
 func (u *UI) loadContainers() {
 	currentRow, _ := u.table.GetSelection()
+	offset, _ := u.table.GetOffset()
 	u.showLoading(containersTitle)
-	go func(selectedRow int) {
-		containers, err := u.docker.ListContainers()
+	u.clearInspect(filter.ResourceContainers)
+	f := u.filter
+	q := f.ContainerQuery()
+	q.Size = u.columnVisible("containers", "SIZE")
+	go func(selectedRow, offset int) {
+		containers, err := u.docker.QueryContainers(q)
+		total := len(containers)
+		if err == nil && f.Narrows(filter.ResourceContainers) {
+			if n, countErr := u.docker.CountContainers(); countErr == nil {
+				total = n
+			}
+		}
 		u.app.QueueUpdateDraw(func() {
+			if !u.loadServes("containers", f) {
+				return
+			}
+			if err == nil {
+				u.loadedFilters["containers"] = f
+				u.totals["containers"] = total
+			}
 			u.renderContainers(containers, err, selectedRow)
+			u.table.SetOffset(offset, 0)
 		})
-	}(currentRow)
+	}(currentRow, offset)
 }
 
 func (u *UI) loadImages() {
 	currentRow, _ := u.table.GetSelection()
+	offset, _ := u.table.GetOffset()
 	u.showLoading(imagesTitle)
-	go func(selectedRow int) {
-		images, err := u.docker.ListImages()
+	u.clearInspect(filter.ResourceImages)
+	f := u.filter
+	go func(selectedRow, offset int) {
+		images, err := u.docker.QueryImages(f.ServerFilters(filter.ResourceImages))
+		total := len(images)
+		if err == nil && f.Narrows(filter.ResourceImages) {
+			if n, countErr := u.docker.CountImages(); countErr == nil {
+				total = n
+			}
+		}
 		u.app.QueueUpdateDraw(func() {
+			if !u.loadServes("images", f) {
+				return
+			}
+			if err == nil {
+				u.loadedFilters["images"] = f
+				u.totals["images"] = total
+			}
 			u.renderImages(images, err, selectedRow)
+			u.table.SetOffset(offset, 0)
 		})
-	}(currentRow)
+	}(currentRow, offset)
 }
 
 func (u *UI) loadNetworks() {
 	currentRow, _ := u.table.GetSelection()
+	offset, _ := u.table.GetOffset()
 	u.showLoading(networksTitle)
-	go func(selectedRow int) {
-		networks, err := u.docker.ListNetworks()
+	u.clearInspect(filter.ResourceNetworks)
+	f := u.filter
+	go func(selectedRow, offset int) {
+		networks, err := u.docker.QueryNetworks(f.ServerFilters(filter.ResourceNetworks))
+		total := len(networks)
+		if err == nil && f.Narrows(filter.ResourceNetworks) {
+			if n, countErr := u.docker.CountNetworks(); countErr == nil {
+				total = n
+			}
+		}
 		u.app.QueueUpdateDraw(func() {
+			if !u.loadServes("networks", f) {
+				return
+			}
+			if err == nil {
+				u.loadedFilters["networks"] = f
+				u.totals["networks"] = total
+			}
 			u.renderNetworks(networks, err, selectedRow)
+			u.table.SetOffset(offset, 0)
 		})
-	}(currentRow)
+	}(currentRow, offset)
 }
 
 func (u *UI) loadVolumes() {
 	currentRow, _ := u.table.GetSelection()
+	offset, _ := u.table.GetOffset()
 	u.showLoading(volumesTitle)
-	go func(selectedRow int) {
-		volumes, err := u.docker.ListVolumes()
+	u.clearInspect(filter.ResourceVolumes)
+	f := u.filter
+	go func(selectedRow, offset int) {
+		volumes, err := u.docker.QueryVolumes(f.ServerFilters(filter.ResourceVolumes))
+		total := len(volumes)
+		if err == nil && f.Narrows(filter.ResourceVolumes) {
+			if n, countErr := u.docker.CountVolumes(); countErr == nil {
+				total = n
+			}
+		}
 		u.app.QueueUpdateDraw(func() {
+			if !u.loadServes("volumes", f) {
+				return
+			}
+			if err == nil {
+				u.loadedFilters["volumes"] = f
+				u.totals["volumes"] = total
+			}
 			u.renderVolumes(volumes, err, selectedRow)
+			u.table.SetOffset(offset, 0)
 		})
-	}(currentRow)
+	}(currentRow, offset)
 }
 
 func (u *UI) renderContainers(containers []docker.ContainerInfo, err error, selectedRow int) {
 	u.table.Clear()
 	u.table.SetTitle(containersTitle)
 	if err != nil {
+		u.shownContainers = nil
 		u.table.SetCell(0, 0, tview.NewTableCell("Error: "+err.Error()).
 			SetTextColor(tcell.ColorRed))
 		return
 	}
 
 	u.containers = containers
+	u.drawContainers(selectedRow)
+}
 
-	// Apply filters
-	filtered := make([]docker.ContainerInfo, 0, len(containers))
-	for _, c := range containers {
-		if u.filter.MatchContainer(c) {
-			filtered = append(filtered, c)
+// drawContainers renders the cached containers that pass the active filter.
+func (u *UI) drawContainers(selectedRow int) {
+	filtered := make([]docker.ContainerInfo, 0, len(u.containers))
+	var pending []string
+	if u.filter.IsFuzzy() {
+		filtered = matchFuzzy(u, u.containers, containerTable.id, u.filter.FuzzyContainer)
+	} else {
+		for _, c := range u.containers {
+			if u.filter.MatchContainer(c) {
+				filtered = append(filtered, c)
+			}
+			if u.filter.InspectPendingContainer(c) {
+				pending = append(pending, c.ID)
+			}
 		}
 	}
-
-	headers := []string{"STATUS", "NAME", "AGE", "IMAGE", "CPU", "MEMORY", "NET I/O", "PORTS"}
-	for col, header := range headers {
-		u.table.SetCell(0, col, tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false).
-			SetExpansion(1).
-			SetAttributes(tcell.AttrBold))
+	u.queueInspect("containers", pending)
+	containerTable.sort(filtered, u.sortKeys())
+	if u.filter.IsFuzzy() {
+		rankByScore(filtered, containerTable.id, u.fuzzyMatches)
 	}
+	selectedRow = followSelection(u.shownContainers, filtered, selectedRow, containerTable.id)
+	u.shownContainers = filtered
 
-	for i, c := range filtered {
-		statusSymbol := "●"
-		statusColor := tcell.ColorRed
-		if c.State == "running" {
-			statusColor = tcell.ColorGreen
-		} else if c.State == "paused" {
-			statusColor = tcell.ColorYellow
-		}
+	u.table.Clear()
+	u.table.SetTitle(u.countTitle(containersTitle, len(filtered), u.totals["containers"]))
+	drawTable(u, containerTable, filtered)
+	u.restoreSelection(selectedRow, len(filtered))
+}
 
-		row := i + 1
-		u.table.SetCell(row, 0, tview.NewTableCell(statusSymbol).
-			SetTextColor(statusColor).
-			SetAlign(tview.AlignCenter).
-			SetExpansion(1))
-		u.table.SetCell(row, 1, tview.NewTableCell(c.Name).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(1))
-		u.table.SetCell(row, 2, tview.NewTableCell(c.Age).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 3, tview.NewTableCell(c.Image).
-			SetTextColor(tcell.ColorLightBlue).
-			SetExpansion(1))
-		u.table.SetCell(row, 4, tview.NewTableCell(c.CPU).
-			SetTextColor(tcell.ColorAqua).
-			SetExpansion(1))
-		u.table.SetCell(row, 5, tview.NewTableCell(c.Memory).
-			SetTextColor(tcell.ColorAqua).
-			SetExpansion(1))
-		u.table.SetCell(row, 6, tview.NewTableCell(c.NetIO).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 7, tview.NewTableCell(c.Ports).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
+func formatImage(c docker.ContainerInfo) string {
+	if c.Outdated {
+		return c.Image + " (outdated)"
 	}
+	return c.Image
+}
 
-	u.restoreSelection(selectedRow, len(filtered))
+func formatRestarts(c docker.ContainerInfo) string {
+	if c.CrashLooping {
+		return fmt.Sprintf("%d (crash loop)", c.RestartCount)
+	}
+	return fmt.Sprintf("%d", c.RestartCount)
+}
+
+func formatExit(c docker.ContainerInfo) string {
+	if c.State == "running" || c.FinishedAt.IsZero() {
+		return "-"
+	}
+	if c.OOMKilled {
+		return fmt.Sprintf("%d (OOM)", c.ExitCode)
+	}
+	return fmt.Sprintf("%d", c.ExitCode)
 }
 
 func (u *UI) renderImages(images []docker.ImageInfo, err error, selectedRow int) {
 	u.table.Clear()
 	u.table.SetTitle(imagesTitle)
 	if err != nil {
+		u.shownImages = nil
 		u.table.SetCell(0, 0, tview.NewTableCell("Error: "+err.Error()).
 			SetTextColor(tcell.ColorRed))
 		return
 	}
 
 	u.images = images
+	u.drawImages(selectedRow)
+}
 
-	// Apply filters
-	filtered := make([]docker.ImageInfo, 0, len(images))
-	for _, img := range images {
-		if u.filter.MatchImage(img) {
-			filtered = append(filtered, img)
+// drawImages renders the cached images that pass the active filter.
+func (u *UI) drawImages(selectedRow int) {
+	filtered := make([]docker.ImageInfo, 0, len(u.images))
+	var pending []string
+	if u.filter.IsFuzzy() {
+		filtered = matchFuzzy(u, u.images, imageTable.id, u.filter.FuzzyImage)
+	} else {
+		for _, img := range u.images {
+			if u.filter.MatchImage(img) {
+				filtered = append(filtered, img)
+			}
+			if u.filter.InspectPendingImage(img) {
+				pending = append(pending, img.ID)
+			}
 		}
 	}
-
-	headers := []string{"ID", "TAG", "SIZE", "AGE"}
-	for col, header := range headers {
-		u.table.SetCell(0, col, tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false).
-			SetExpansion(1).
-			SetAttributes(tcell.AttrBold))
-	}
-
-	for i, img := range filtered {
-		row := i + 1
-		u.table.SetCell(row, 0, tview.NewTableCell(img.ID).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(1))
-		u.table.SetCell(row, 1, tview.NewTableCell(img.Tag).
-			SetTextColor(tcell.ColorLightBlue).
-			SetExpansion(1))
-		u.table.SetCell(row, 2, tview.NewTableCell(img.Size).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 3, tview.NewTableCell(img.Age).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
+	u.queueInspect("images", pending)
+	imageTable.sort(filtered, u.sortKeys())
+	if u.filter.IsFuzzy() {
+		rankByScore(filtered, imageTable.id, u.fuzzyMatches)
 	}
+	selectedRow = followSelection(u.shownImages, filtered, selectedRow, imageTable.id)
+	u.shownImages = filtered
 
+	u.table.Clear()
+	u.table.SetTitle(u.countTitle(imagesTitle, len(filtered), u.totals["images"]))
+	drawTable(u, imageTable, filtered)
 	u.restoreSelection(selectedRow, len(filtered))
 }
 
@@ -709,50 +943,43 @@ func (u *UI) renderNetworks(networks []docker.NetworkInfo, err error, selectedRo
 	u.table.Clear()
 	u.table.SetTitle(networksTitle)
 	if err != nil {
+		u.shownNetworks = nil
 		u.table.SetCell(0, 0, tview.NewTableCell("Error: "+err.Error()).
 			SetTextColor(tcell.ColorRed))
 		return
 	}
 
 	u.networks = networks
+	u.drawNetworks(selectedRow)
+}
 
-	// Apply filters
-	filtered := make([]docker.NetworkInfo, 0, len(networks))
-	for _, net := range networks {
-		if u.filter.MatchNetwork(net) {
-			filtered = append(filtered, net)
+// drawNetworks renders the cached networks that pass the active filter.
+func (u *UI) drawNetworks(selectedRow int) {
+	filtered := make([]docker.NetworkInfo, 0, len(u.networks))
+	var pending []string
+	if u.filter.IsFuzzy() {
+		filtered = matchFuzzy(u, u.networks, networkTable.id, u.filter.FuzzyNetwork)
+	} else {
+		for _, net := range u.networks {
+			if u.filter.MatchNetwork(net) {
+				filtered = append(filtered, net)
+			}
+			if u.filter.InspectPendingNetwork(net) {
+				pending = append(pending, net.ID)
+			}
 		}
 	}
-
-	headers := []string{"ID", "NAME", "AGE", "DRIVER", "SCOPE"}
-	for col, header := range headers {
-		u.table.SetCell(0, col, tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false).
-			SetExpansion(1).
-			SetAttributes(tcell.AttrBold))
-	}
-
-	for i, net := range filtered {
-		row := i + 1
-		u.table.SetCell(row, 0, tview.NewTableCell(net.ID).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(1))
-		u.table.SetCell(row, 1, tview.NewTableCell(net.Name).
-			SetTextColor(tcell.ColorLightBlue).
-			SetExpansion(1))
-		u.table.SetCell(row, 2, tview.NewTableCell(net.Age).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 3, tview.NewTableCell(net.Driver).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 4, tview.NewTableCell(net.Scope).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
+	u.queueInspect("networks", pending)
+	networkTable.sort(filtered, u.sortKeys())
+	if u.filter.IsFuzzy() {
+		rankByScore(filtered, networkTable.id, u.fuzzyMatches)
 	}
+	selectedRow = followSelection(u.shownNetworks, filtered, selectedRow, networkTable.id)
+	u.shownNetworks = filtered
 
+	u.table.Clear()
+	u.table.SetTitle(u.countTitle(networksTitle, len(filtered), u.totals["networks"]))
+	drawTable(u, networkTable, filtered)
 	u.restoreSelection(selectedRow, len(filtered))
 }
 
@@ -760,47 +987,43 @@ func (u *UI) renderVolumes(volumes []docker.VolumeInfo, err error, selectedRow i
 	u.table.Clear()
 	u.table.SetTitle(volumesTitle)
 	if err != nil {
+		u.shownVolumes = nil
 		u.table.SetCell(0, 0, tview.NewTableCell("Error: "+err.Error()).
 			SetTextColor(tcell.ColorRed))
 		return
 	}
 
 	u.volumes = volumes
+	u.drawVolumes(selectedRow)
+}
 
-	// Apply filters
-	filtered := make([]docker.VolumeInfo, 0, len(volumes))
-	for _, vol := range volumes {
-		if u.filter.MatchVolume(vol) {
-			filtered = append(filtered, vol)
+// drawVolumes renders the cached volumes that pass the active filter.
+func (u *UI) drawVolumes(selectedRow int) {
+	filtered := make([]docker.VolumeInfo, 0, len(u.volumes))
+	var pending []string
+	if u.filter.IsFuzzy() {
+		filtered = matchFuzzy(u, u.volumes, volumeTable.id, u.filter.FuzzyVolume)
+	} else {
+		for _, vol := range u.volumes {
+			if u.filter.MatchVolume(vol) {
+				filtered = append(filtered, vol)
+			}
+			if u.filter.InspectPendingVolume(vol) {
+				pending = append(pending, vol.Name)
+			}
 		}
 	}
-
-	headers := []string{"NAME", "AGE", "DRIVER", "MOUNTPOINT"}
-	for col, header := range headers {
-		u.table.SetCell(0, col, tview.NewTableCell(header).
-			SetTextColor(tcell.ColorYellow).
-			SetAlign(tview.AlignCenter).
-			SetSelectable(false).
-			SetExpansion(1).
-			SetAttributes(tcell.AttrBold))
-	}
-
-	for i, vol := range filtered {
-		row := i + 1
-		u.table.SetCell(row, 0, tview.NewTableCell(vol.Name).
-			SetTextColor(tcell.ColorWhite).
-			SetExpansion(1))
-		u.table.SetCell(row, 1, tview.NewTableCell(vol.Age).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
-		u.table.SetCell(row, 2, tview.NewTableCell(vol.Driver).
-			SetTextColor(tcell.ColorLightBlue).
-			SetExpansion(1))
-		u.table.SetCell(row, 3, tview.NewTableCell(vol.Mountpoint).
-			SetTextColor(tcell.ColorGray).
-			SetExpansion(1))
+	u.queueInspect("volumes", pending)
+	volumeTable.sort(filtered, u.sortKeys())
+	if u.filter.IsFuzzy() {
+		rankByScore(filtered, volumeTable.id, u.fuzzyMatches)
 	}
+	selectedRow = followSelection(u.shownVolumes, filtered, selectedRow, volumeTable.id)
+	u.shownVolumes = filtered
 
+	u.table.Clear()
+	u.table.SetTitle(u.countTitle(volumesTitle, len(filtered), u.totals["volumes"]))
+	drawTable(u, volumeTable, filtered)
 	u.restoreSelection(selectedRow, len(filtered))
 }
 
@@ -822,6 +1045,12 @@ func (u *UI) Run() error {
 		AddItem(u.table, 0, 1, true).
 		AddItem(u.statusBar, 1, 0, false)
 
+	defer func() {
+		if u.volumeBrowser != nil {
+			u.volumeBrowser.browser.Close()
+		}
+	}()
+
 	if err := u.app.SetRoot(u.mainView, true).Run(); err != nil {
 		return fmt.Errorf("TUI error: %v", err)
 	}
@@ -1,9 +1,16 @@
 package ui
 
 import (
+	"reflect"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+	"dock-it/internal/filter"
+	"dock-it/internal/settings"
 )
 
 func TestRestoreSelection(t *testing.T) {
@@ -35,3 +42,189 @@ func TestRestoreSelection(t *testing.T) {
 		})
 	}
 }
+
+func TestContainerSort(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	rows := []docker.ContainerInfo{
+		{ID: "a", Name: "web", Created: now.Add(-time.Hour), Usage: &docker.ResourceUsage{CPUPercent: 9.5, MemoryPercent: 50, MemoryBytes: 100 << 20}},
+		{ID: "b", Name: "db", Created: now.Add(-time.Minute), Usage: &docker.ResourceUsage{CPUPercent: 80, MemoryPercent: 5, MemoryBytes: 1 << 30}},
+		{ID: "c", Name: "Worker", Created: now.Add(-time.Hour)},
+		{ID: "d", Name: "cache", Created: now.Add(-time.Hour), Usage: &docker.ResourceUsage{CPUPercent: 9.5, MemoryPercent: 20, MemoryBytes: 200 << 20}},
+	}
+
+	tests := []struct {
+		name string
+		keys []settings.SortKey
+		want string
+	}{
+		{"unsorted", nil, "abcd"},
+		{"cpu numerically", []settings.SortKey{{Column: "CPU"}}, "cadb"},
+		{"cpu descending, ties by id", []settings.SortKey{{Column: "CPU", Desc: true}}, "badc"},
+		{"memory by the shown percentage", []settings.SortKey{{Column: "MEMORY"}}, "cbda"},
+		{"age youngest first then name", []settings.SortKey{{Column: "AGE"}, {Column: "NAME"}}, "bdac"},
+		{"name ignores case", []settings.SortKey{{Column: "NAME", Desc: true}}, "cabd"},
+		{"unknown column", []settings.SortKey{{Column: "BOGUS"}}, "abcd"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sorted := slices.Clone(rows)
+			containerTable.sort(sorted, tt.keys)
+			var got string
+			for _, c := range sorted {
+				got += c.ID
+			}
+			if got != tt.want {
+				t.Fatalf("sorted order = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFollowSelection(t *testing.T) {
+	t.Parallel()
+
+	prev := []docker.VolumeInfo{{Name: "a"}, {Name: "b"}, {Name: "c"}}
+	rows := []docker.VolumeInfo{{Name: "c"}, {Name: "b"}}
+
+	if got := followSelection(prev, rows, 3, volumeTable.id); got != 1 {
+		t.Fatalf("followSelection moved row = %d, want 1", got)
+	}
+	if got := followSelection(prev, rows, 1, volumeTable.id); got != 1 {
+		t.Fatalf("followSelection removed row = %d, want 1", got)
+	}
+	if got := followSelection(nil, rows, 2, volumeTable.id); got != 2 {
+		t.Fatalf("followSelection without previous rows = %d, want 2", got)
+	}
+}
+
+func TestFuzzyRankAndHighlight(t *testing.T) {
+	t.Parallel()
+
+	f, err := filter.ParseFilter("?web")
+	if err != nil {
+		t.Fatalf("ParseFilter: %v", err)
+	}
+	testUI := &UI{filter: f}
+	rows := []docker.ContainerInfo{
+		{ID: "a1", Name: "my-webapp", Image: "nginx"},
+		{ID: "b2", Name: "db", Image: "postgres"},
+		{ID: "c3", Name: "web", Image: "nginx"},
+	}
+	shown := matchFuzzy(testUI, rows, containerTable.id, f.FuzzyContainer)
+	containerTable.sort(shown, nil)
+	rankByScore(shown, containerTable.id, testUI.fuzzyMatches)
+	var names []string
+	for _, c := range shown {
+		names = append(names, c.Name)
+	}
+	if want := []string{"web", "my-webapp"}; !slices.Equal(names, want) {
+		t.Fatalf("shown = %v, want %v", names, want)
+	}
+
+	testUI.rowMatch = testUI.fuzzyMatches["c3"]
+	if got, want := testUI.highlight("web", true), highlightStyle+"web[-:-]"; got != want {
+		t.Errorf("highlight(name) = %q, want %q", got, want)
+	}
+	if got := testUI.highlight("nginx", true); got != "nginx" {
+		t.Errorf("highlight(image) = %q, want it unmarked: no term scored there", got)
+	}
+}
+
+func TestResolveColumns(t *testing.T) {
+	t.Parallel()
+
+	defaults := []settings.ColumnSettings{
+		{Name: "STATUS", Align: settings.AlignCenter},
+		{Name: "NAME"},
+		{Name: "AGE"},
+		{Name: "ID", Hidden: true},
+	}
+	saved := []settings.ColumnSettings{
+		{Name: "ID"},
+		{Name: "GONE"},
+		{Name: "STATUS", Width: 3},
+		{Name: "AGE", Hidden: true, Align: settings.AlignRight},
+	}
+	want := []settings.ColumnSettings{
+		{Name: "ID"},
+		{Name: "STATUS", Width: 3, Align: settings.AlignCenter},
+		{Name: "AGE", Hidden: true, Align: settings.AlignRight},
+		{Name: "NAME"},
+	}
+
+	if got := resolveColumns(defaults, saved); !reflect.DeepEqual(got, want) {
+		t.Fatalf("resolveColumns() = %v, want %v", got, want)
+	}
+	if got := resolveColumns(defaults, nil); !reflect.DeepEqual(got, defaults) {
+		t.Fatalf("resolveColumns() without saved layout = %v, want %v", got, defaults)
+	}
+}
+
+func TestRunFormRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	spec := docker.RunSpec{
+		Image:    "nginx:1.27",
+		Name:     "web",
+		Command:  []string{"nginx", "-g", "daemon off;"},
+		Env:      []string{"A=1"},
+		Ports:    []string{"8080:80"},
+		Mounts:   []string{"data:/data"},
+		Networks: []string{"appnet"},
+		Labels:   map[string]string{"app": "web"},
+		Resources: docker.ResourceSettings{
+			Memory:        512 * 1024 * 1024,
+			MemorySwap:    1024 * 1024 * 1024,
+			CPUShares:     512,
+			CPUQuota:      50000,
+			CPUPeriod:     100000,
+			CpusetCpus:    "0-1",
+			PidsLimit:     100,
+			RestartPolicy: "on-failure",
+			MaxRetries:    3,
+		},
+	}
+
+	form := tview.NewForm()
+	addRunFields(form, docker.RunSpec{Resources: docker.ResourceSettings{RestartPolicy: "no"}}.Input())
+	fillRunForm(form, spec.Input())
+	got, err := readRunForm(form).Parse()
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, spec) {
+		t.Fatalf("round trip = %+v, want %+v", got, spec)
+	}
+}
+
+func TestInspectCacheIgnoresClearedGenerations(t *testing.T) {
+	c := newInspectCache()
+	ids, stale := c.claim(filter.ResourceContainers, []string{"a", "b"})
+	if len(ids) != 2 {
+		t.Fatalf("claim() = %v, want both ids", ids)
+	}
+	c.clear(filter.ResourceContainers)
+
+	if c.set(filter.ResourceContainers, stale, "a", "old") {
+		t.Fatalf("set() stored a result claimed before clear")
+	}
+	if _, ok := c.Inspected(filter.ResourceContainers, "a"); ok {
+		t.Fatalf("Inspected() found a result claimed before clear")
+	}
+
+	ids, current := c.claim(filter.ResourceContainers, []string{"a"})
+	if len(ids) != 1 || current == stale {
+		t.Fatalf("claim() after clear = %v, generation %d", ids, current)
+	}
+	if !c.set(filter.ResourceContainers, current, "a", "new") {
+		t.Fatalf("set() rejected a current result")
+	}
+	if data, _ := c.Inspected(filter.ResourceContainers, "a"); data != "new" {
+		t.Fatalf("Inspected() = %v, want new", data)
+	}
+}
@@ -0,0 +1,37 @@
+package ui
+
+import (
+	"dock-it/internal/filter"
+)
+
+// viewState is what a view keeps while another view is shown: its filter and
+// the table's selected row and scroll offset.
+type viewState struct {
+	filter *filter.Filter
+	row    int
+	offset int
+}
+
+// switchView shows view, saving the state of the current view and restoring
+// the state view had when it was last left. A view not shown before starts
+// unfiltered at the top.
+func (u *UI) switchView(view string) {
+	row, _ := u.table.GetSelection()
+	offset, _ := u.table.GetOffset()
+	u.viewStates[u.currentView] = viewState{filter: u.filter, row: row, offset: offset}
+
+	state, ok := u.viewStates[view]
+	if !ok {
+		state = viewState{filter: filter.New(), row: 1}
+	}
+	u.currentView = view
+	u.filter = state.filter
+	u.filterInput.SetText(u.filter.String())
+	u.updateStatusBarText()
+
+	// The load restores the selection and offset the table has when it
+	// starts.
+	u.table.Select(state.row, 0)
+	u.table.SetOffset(state.offset, 0)
+	u.reloadCurrentView()
+}
@@ -0,0 +1,145 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/go-units"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	fieldHelperImage = "Helper image"
+	fieldTarPath     = "Tar file"
+	fieldStopUsers   = "Stop containers using the volume"
+
+	progressInterval = 250 * time.Millisecond
+)
+
+// showVolumeTransferForm opens the backup (restore=false) or restore form
+// for a volume once the list of local images is known.
+func (u *UI) showVolumeTransferForm(vol docker.VolumeInfo, restore bool) {
+	u.setStatusMessage("[yellow]Loading local images...")
+	go func() {
+		images, err := u.docker.ListImages()
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]Load images failed: %v", err))
+				return
+			}
+			tags := localImageTags(images)
+			if len(tags) == 0 {
+				u.statusBar.SetText("[red]No tagged local image available for the helper container")
+				return
+			}
+			u.buildVolumeTransferForm(vol, restore, tags)
+		})
+	}()
+}
+
+func (u *UI) buildVolumeTransferForm(vol docker.VolumeInfo, restore bool, tags []string) {
+	action := "Backup"
+	path := fmt.Sprintf("./%s-%s.tar", vol.Name, time.Now().Format("20060102-150405"))
+	if restore {
+		action = "Restore"
+		path = ""
+	}
+
+	running := 0
+	for _, user := range vol.UsedBy {
+		if user.State == "running" {
+			running++
+		}
+	}
+
+	form := tview.NewForm().
+		AddDropDown(fieldHelperImage, tags, 0, nil).
+		AddInputField(fieldTarPath, path, 50, nil, nil).
+		AddCheckbox(fieldStopUsers, running > 0, nil)
+
+	form.AddButton(action, func() {
+		opts := docker.VolumeTransferOptions{
+			Volume:    vol.Name,
+			Path:      strings.TrimSpace(formText(form, fieldTarPath)),
+			StopUsers: formChecked(form, fieldStopUsers),
+		}
+		if opts.Path == "" {
+			u.statusBar.SetText("[red]Tar file path is required")
+			return
+		}
+		if dd, ok := form.GetFormItemByLabel(fieldHelperImage).(*tview.DropDown); ok {
+			_, opts.Image = dd.GetCurrentOption()
+		}
+		u.transferVolume(opts, restore)
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" %s Volume: %s ", action, vol.Name))
+	u.showForm(form)
+}
+
+func (u *UI) transferVolume(opts docker.VolumeTransferOptions, restore bool) {
+	action := "Backup"
+	run := u.docker.BackupVolume
+	if restore {
+		action = "Restore"
+		run = u.docker.RestoreVolume
+	}
+	label := fmt.Sprintf("%s %s", action, opts.Volume)
+	u.setStatusMessage(fmt.Sprintf("[yellow]%s...", label))
+
+	go func() {
+		var last time.Time
+		result, err := run(opts, func(done, total int64) {
+			if time.Since(last) < progressInterval {
+				return
+			}
+			last = time.Now()
+			msg := fmt.Sprintf("[yellow]%s: %s", label, units.BytesSize(float64(done)))
+			if total > 0 {
+				msg = fmt.Sprintf("[yellow]%s: %s / %s (%d%%)", label, units.BytesSize(float64(done)), units.BytesSize(float64(total)), done*100/total)
+			}
+			u.app.QueueUpdateDraw(func() {
+				u.setStatusMessage(msg)
+			})
+		})
+		u.app.QueueUpdateDraw(func() {
+			u.showDetail(fmt.Sprintf(" %s ", label), func() (string, error) {
+				return formatVolumeTransfer(action, result, err), nil
+			})
+		})
+	}()
+}
+
+func formatVolumeTransfer(action string, result docker.VolumeTransferResult, err error) string {
+	var b strings.Builder
+	if err != nil {
+		fmt.Fprintf(&b, "[red]%s failed:[white] %s\n", action, tview.Escape(err.Error()))
+	} else {
+		fmt.Fprintf(&b, "[green]%s complete[white]\n", action)
+		fmt.Fprintf(&b, "  file:  %s\n", tview.Escape(result.Path))
+		fmt.Fprintf(&b, "  size:  %s\n", units.BytesSize(float64(result.Bytes)))
+	}
+	if len(result.Stopped) > 0 {
+		fmt.Fprintf(&b, "  stopped and restarted: %s\n", tview.Escape(strings.Join(result.Stopped, ", ")))
+	}
+	return b.String()
+}
+
+func localImageTags(images []docker.ImageInfo) []string {
+	var tags []string
+	for _, img := range images {
+		if img.Tag != "<none>" && img.Tag != "" {
+			tags = append(tags, img.Tag)
+		}
+	}
+	return tags
+}
@@ -0,0 +1,270 @@
+package ui
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/docker/go-units"
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	browserStatusText = "[yellow]Enter[white]:open [yellow]Backspace[white]:up [yellow]s[white]:save file [yellow]ESC/q[white]:close"
+	fieldSaveTo       = "Save to"
+)
+
+// volumeBrowser holds the state of an open volume file browser.
+type volumeBrowser struct {
+	browser *docker.VolumeBrowser
+	table   *tview.Table
+	dir     string
+	entries []docker.VolumeEntry
+}
+
+// showVolumeBrowserForm asks for the helper image to browse a volume with,
+// preferring busybox or alpine since the helper lists directories with
+// find and stat.
+func (u *UI) showVolumeBrowserForm(vol docker.VolumeInfo) {
+	u.setStatusMessage("[yellow]Loading local images...")
+	go func() {
+		images, err := u.docker.ListImages()
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]Load images failed: %v", err))
+				return
+			}
+			tags := localImageTags(images)
+			if len(tags) == 0 {
+				u.statusBar.SetText("[red]No tagged local image available for the helper container")
+				return
+			}
+
+			form := tview.NewForm().
+				AddDropDown(fieldHelperImage, tags, preferredHelperImage(tags), nil)
+			form.AddButton("Browse", func() {
+				if dd, ok := form.GetFormItemByLabel(fieldHelperImage).(*tview.DropDown); ok {
+					_, image := dd.GetCurrentOption()
+					u.openVolumeBrowser(vol, image)
+				}
+			})
+			form.AddButton("Cancel", func() {
+				u.switchToTableView()
+			})
+			form.SetCancelFunc(func() {
+				u.switchToTableView()
+			})
+			form.SetBorder(true).SetTitle(fmt.Sprintf(" Browse Volume: %s ", tview.Escape(vol.Name)))
+			u.showForm(form)
+			u.statusBar.SetText("[yellow]The helper image needs sleep, find and stat, e.g. busybox or alpine")
+		})
+	}()
+}
+
+// openVolumeBrowser starts the helper container for a volume and shows its
+// root directory.
+func (u *UI) openVolumeBrowser(vol docker.VolumeInfo, image string) {
+	u.switchToTableView()
+	u.setStatusMessage(fmt.Sprintf("[yellow]Opening %s...", vol.Name))
+	go func() {
+		browser, err := u.docker.OpenVolumeBrowser(vol.Name, image)
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]Browse %s failed: %v", vol.Name, err))
+				return
+			}
+			vb := &volumeBrowser{browser: browser, table: tview.NewTable().SetSelectable(true, false).SetFixed(1, 0)}
+			vb.table.SetBorder(true)
+			u.volumeBrowser = vb
+			u.setupVolumeBrowserKeys(vb)
+			u.showVolumeBrowser(vb)
+			u.browseTo(vb, "/")
+		})
+	}()
+}
+
+// preferredHelperImage returns the index of the first busybox or alpine
+// image in tags, or 0 without one.
+func preferredHelperImage(tags []string) int {
+	for i, tag := range tags {
+		repo, _, _ := strings.Cut(tag, ":")
+		if repo == "busybox" || repo == "alpine" {
+			return i
+		}
+	}
+	return 0
+}
+
+func (u *UI) showVolumeBrowser(vb *volumeBrowser) {
+	u.viewMode = "browser"
+	u.updateStatusBarText()
+
+	u.mainView.Clear()
+	u.mainView.AddItem(vb.table, 0, 1, true)
+	u.mainView.AddItem(u.statusBar, 1, 0, false)
+	u.app.SetFocus(vb.table)
+}
+
+func (u *UI) closeVolumeBrowser(vb *volumeBrowser) {
+	u.volumeBrowser = nil
+	u.switchToTableView()
+	go func() {
+		if err := vb.browser.Close(); err != nil {
+			u.app.QueueUpdateDraw(func() {
+				u.statusBar.SetText(fmt.Sprintf("[red]%v", err))
+			})
+		}
+	}()
+}
+
+func (u *UI) browseTo(vb *volumeBrowser, dir string) {
+	dir = path.Clean("/" + dir)
+	vb.table.Clear()
+	vb.table.SetTitle(fmt.Sprintf(" Volume %s: %s ", vb.browser.Volume, dir))
+	vb.table.SetCell(0, 0, tview.NewTableCell("Loading...").SetSelectable(false).SetTextColor(tcell.ColorGray))
+
+	go func() {
+		entries, err := vb.browser.List(dir)
+		u.app.QueueUpdateDraw(func() {
+			if err != nil {
+				u.statusBar.SetText(fmt.Sprintf("[red]List %s failed: %v", dir, err))
+				if vb.dir == "" {
+					vb.table.SetCell(0, 0, tview.NewTableCell("Error: "+err.Error()).SetTextColor(tcell.ColorRed))
+					return
+				}
+				// Keep showing the directory we were in.
+				u.renderVolumeEntries(vb)
+				return
+			}
+			vb.dir = dir
+			vb.entries = entries
+			u.renderVolumeEntries(vb)
+		})
+	}()
+}
+
+func (u *UI) renderVolumeEntries(vb *volumeBrowser) {
+	vb.table.Clear()
+	vb.table.SetTitle(fmt.Sprintf(" Volume %s: %s ", vb.browser.Volume, vb.dir))
+
+	headers := []string{"NAME", "SIZE", "MODIFIED", "MODE"}
+	for col, header := range headers {
+		vb.table.SetCell(0, col, tview.NewTableCell(header).
+			SetTextColor(tcell.ColorYellow).
+			SetSelectable(false).
+			SetExpansion(1).
+			SetAttributes(tcell.AttrBold))
+	}
+
+	row := 1
+	if vb.dir != "/" {
+		vb.table.SetCell(row, 0, tview.NewTableCell("../").SetTextColor(tcell.ColorLightBlue).SetExpansion(1))
+		row++
+	}
+	for _, e := range vb.entries {
+		name, color := e.Name, tcell.ColorWhite
+		switch {
+		case e.Dir:
+			name, color = e.Name+"/", tcell.ColorLightBlue
+		case e.Link != "":
+			name, color = e.Name+" -> "+e.Link, tcell.ColorGray
+		}
+		modified := "-"
+		if !e.ModTime.IsZero() {
+			modified = e.ModTime.Local().Format("2006-01-02 15:04:05")
+		}
+		vb.table.SetCell(row, 0, tview.NewTableCell(tview.Escape(name)).SetTextColor(color).SetExpansion(1))
+		size := "-"
+		if !e.Dir {
+			size = units.BytesSize(float64(e.Size))
+		}
+		vb.table.SetCell(row, 1, tview.NewTableCell(size).SetTextColor(tcell.ColorWhite).SetExpansion(1))
+		vb.table.SetCell(row, 2, tview.NewTableCell(modified).SetTextColor(tcell.ColorGray).SetExpansion(1))
+		vb.table.SetCell(row, 3, tview.NewTableCell(e.Mode.String()).SetTextColor(tcell.ColorGray).SetExpansion(1))
+		row++
+	}
+	vb.table.Select(1, 0)
+	vb.table.ScrollToBeginning()
+}
+
+// selectedEntry returns the entry under the cursor; ok is false for the
+// parent directory row.
+func (vb *volumeBrowser) selectedEntry() (docker.VolumeEntry, bool) {
+	row, _ := vb.table.GetSelection()
+	idx := row - 1
+	if vb.dir != "/" {
+		idx--
+	}
+	if idx < 0 || idx >= len(vb.entries) {
+		return docker.VolumeEntry{}, false
+	}
+	return vb.entries[idx], true
+}
+
+func (u *UI) setupVolumeBrowserKeys(vb *volumeBrowser) {
+	vb.table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		switch event.Key() {
+		case tcell.KeyEscape:
+			u.closeVolumeBrowser(vb)
+			return nil
+		case tcell.KeyBackspace, tcell.KeyBackspace2:
+			u.browseTo(vb, path.Dir(vb.dir))
+			return nil
+		case tcell.KeyEnter:
+			entry, ok := vb.selectedEntry()
+			switch {
+			case !ok:
+				u.browseTo(vb, path.Dir(vb.dir))
+			case entry.Dir:
+				u.browseTo(vb, path.Join(vb.dir, entry.Name))
+			}
+			return nil
+		}
+
+		switch event.Rune() {
+		case 'q':
+			u.closeVolumeBrowser(vb)
+			return nil
+		case 's':
+			if entry, ok := vb.selectedEntry(); ok && !entry.Dir && entry.Link == "" {
+				u.showSaveFileForm(vb, entry)
+			}
+			return nil
+		}
+		return event
+	})
+}
+
+func (u *UI) showSaveFileForm(vb *volumeBrowser, entry docker.VolumeEntry) {
+	file := path.Join(vb.dir, entry.Name)
+	form := tview.NewForm().
+		AddInputField(fieldSaveTo, "./"+entry.Name, 50, nil, nil)
+
+	form.AddButton("Save", func() {
+		dest := formText(form, fieldSaveTo)
+		u.showVolumeBrowser(vb)
+		u.setStatusMessage(fmt.Sprintf("[yellow]Saving %s...", file))
+		go func() {
+			saved, n, err := vb.browser.Download(file, dest)
+			u.app.QueueUpdateDraw(func() {
+				if err != nil {
+					u.statusBar.SetText(fmt.Sprintf("[red]Save %s failed: %v", file, err))
+					return
+				}
+				u.statusBar.SetText(fmt.Sprintf("[green]Saved %s to %s (%s)", file, saved, units.BytesSize(float64(n))))
+			})
+		}()
+	})
+	form.AddButton("Cancel", func() {
+		u.showVolumeBrowser(vb)
+	})
+	form.SetCancelFunc(func() {
+		u.showVolumeBrowser(vb)
+	})
+
+	form.SetBorder(true).SetTitle(fmt.Sprintf(" Save %s ", file))
+	u.showForm(form)
+}
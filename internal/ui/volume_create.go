@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rivo/tview"
+
+	"dock-it/internal/docker"
+)
+
+const (
+	fieldVolumeName    = "Name (empty: generated)"
+	fieldVolumeDriver  = "Driver"
+	fieldVolumeOptions = `Driver options (type=nfs, o=addr=host\,rw)`
+	fieldVolumeLabels  = "Labels (key=value, ...)"
+)
+
+// showVolumeCreateForm opens a form for creating a volume.
+func (u *UI) showVolumeCreateForm() {
+	form := tview.NewForm().
+		AddInputField(fieldVolumeName, "", 30, nil, nil).
+		AddInputField(fieldVolumeDriver, "local", 20, nil, nil).
+		AddInputField(fieldVolumeOptions, "", 50, nil, nil).
+		AddInputField(fieldVolumeLabels, "", 50, nil, nil)
+
+	form.AddButton("Create", func() {
+		spec, err := docker.VolumeInput{
+			Name:    formText(form, fieldVolumeName),
+			Driver:  formText(form, fieldVolumeDriver),
+			Options: formText(form, fieldVolumeOptions),
+			Labels:  formText(form, fieldVolumeLabels),
+		}.Parse()
+		if err != nil {
+			u.statusBar.SetText(fmt.Sprintf("[red]Invalid settings: %s", strings.ReplaceAll(err.Error(), "\n", "; ")))
+			return
+		}
+		label := "Create volume"
+		if spec.Name != "" {
+			label = fmt.Sprintf("Create volume %s", spec.Name)
+		}
+		u.runAsyncAction(label, func() error {
+			_, err := u.docker.CreateVolume(spec)
+			return err
+		}, func() {
+			u.switchToTableView()
+		})
+	})
+	form.AddButton("Cancel", func() {
+		u.switchToTableView()
+	})
+	form.SetCancelFunc(func() {
+		u.switchToTableView()
+	})
+
+	form.SetBorder(true).SetTitle(" Create Volume ")
+	u.showForm(form)
+}